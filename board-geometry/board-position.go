@@ -25,7 +25,9 @@ type marshallableStringVersion struct {
 	Angle  string
 }
 
-func (bp *BoardPosition) MarshalText() ([]byte, error) {
+// MarshalText encodes a board position as text.  It has a value receiver so that positions can be used as
+// the keys of a map written as JSON (map keys are not addressable, so a pointer method would not be found).
+func (bp BoardPosition) MarshalText() ([]byte, error) {
 	marshallable := marshallableStringVersion{
 		Radius: strconv.FormatFloat(bp.Radius, 'g', -1, 64),
 		Angle:  strconv.FormatFloat(bp.Angle, 'g', -1, 64),
@@ -48,7 +50,7 @@ func (bp *BoardPosition) UnmarshalText(bytes []byte) error {
 	}
 	angle, err := strconv.ParseFloat(umsv.Angle, 64)
 	if err != nil {
-		fmt.Println("Error parsing angle %s: %s", umsv.Angle, err)
+		fmt.Printf("Error parsing angle %s: %s", umsv.Angle, err)
 		return err
 	}
 	bp.Radius = radius
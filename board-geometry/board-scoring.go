@@ -83,3 +83,58 @@ func determineMultiplier(radius float64) int {
 	}
 	return multiplierList[foundMultiplierIndex]
 }
+
+// ScoringRegion is one of the separately-scoring areas of the board: the part of a ring between two radii and
+// two angles.  The bulls are complete circles, from angle 0 to 360.
+type ScoringRegion struct {
+	Area         BoardArea
+	SegmentValue int // Point value of the segment, before the multiplier (25 for both bulls)
+	Multiplier   int
+	Score        int
+	InnerRadius  float64 // Normalized radius units
+	OuterRadius  float64
+	StartAngle   float64 // Degrees, clockwise from straight up.  Negative for the left half of the 20 segment
+	EndAngle     float64
+}
+
+// ScoringRegions returns every scoring region of the board: the two bulls, then the inner single, treble,
+// outer single, and double areas of each segment, clockwise from the 20.  Together they cover the whole
+// scoring area without overlapping; anything outside them scores nothing.
+func ScoringRegions() []ScoringRegion {
+	regions := make([]ScoringRegion, 0, 2+4*len(segmentPointValues))
+	regions = append(regions,
+		ScoringRegion{Area: BoardArea_InnerBull, SegmentValue: 25, Multiplier: 2, Score: 50,
+			InnerRadius: 0, OuterRadius: innerBullRadiusNormalized, StartAngle: 0, EndAngle: 360},
+		ScoringRegion{Area: BoardArea_OuterBull, SegmentValue: 25, Multiplier: 1, Score: 25,
+			InnerRadius: innerBullRadiusNormalized, OuterRadius: outerBullRadiusNormalized, StartAngle: 0, EndAngle: 360})
+
+	const segmentWidth = 360.0 / 20
+	rings := []struct {
+		area        BoardArea
+		multiplier  int
+		innerRadius float64
+		outerRadius float64
+	}{
+		{BoardArea_InnerSingle, 1, outerBullRadiusNormalized, insideTrebleRadiusNormalized},
+		{BoardArea_Treble, 3, insideTrebleRadiusNormalized, outsideTrebleRadiusNormalized},
+		{BoardArea_OuterSingle, 1, outsideTrebleRadiusNormalized, insideDoubleRadiusNormalized},
+		{BoardArea_Double, 2, insideDoubleRadiusNormalized, outsideDoubleRadiusNormalized},
+	}
+	for i, segmentValue := range segmentPointValues {
+		//	Each segment is centred on a multiple of the segment width, so the 20 runs from -9 to +9 degrees
+		startAngle := float64(i)*segmentWidth - segmentWidth/2
+		for _, ring := range rings {
+			regions = append(regions, ScoringRegion{
+				Area:         ring.area,
+				SegmentValue: segmentValue,
+				Multiplier:   ring.multiplier,
+				Score:        segmentValue * ring.multiplier,
+				InnerRadius:  ring.innerRadius,
+				OuterRadius:  ring.outerRadius,
+				StartAngle:   startAngle,
+				EndAngle:     startAngle + segmentWidth,
+			})
+		}
+	}
+	return regions
+}
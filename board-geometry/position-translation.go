@@ -36,6 +36,9 @@ const insideDoubleRadiusNormalized = insideDoubleRadius / scoringAreaRadius
 const outsideDoubleRadiusNormalized = outsideDoubleRadius / scoringAreaRadius
 const scoringAreaRadiusNormalized = scoringAreaRadius / scoringAreaRadius
 
+// ScoringAreaRadiusMillimetres is the size of one normalized board unit: the radius of the scoring area
+const ScoringAreaRadiusMillimetres = scoringAreaRadius
+
 // ScoringAreaFraction is a scaling factor to normalize mouse positing inside board from 0 to 1 radius
 const ScoringAreaFraction = float64(scoringAreaDiameter) / float64(displayedBoardDiameter)
 
@@ -90,6 +93,27 @@ func GetXY(bp BoardPosition, squareDimension float64) (int, int) {
 
 }
 
+// GetCartesian returns the cartesian equivalent of a board position, in the same normalized units
+// as the polar radius (1.0 is the outer edge of the scoring area). The origin is the centre of the
+// board, with x increasing to the right and y increasing upwards.  No screen or pixel information
+// is involved, so this is suitable for simulation work that must not depend on the window size.
+func GetCartesian(bp BoardPosition) (float64, float64) {
+	x := bp.Radius * math.Sin(bp.Angle*math.Pi/180)
+	y := bp.Radius * math.Cos(bp.Angle*math.Pi/180)
+	return x, y
+}
+
+// CreateBoardPositionFromCartesian creates a BoardPosition from normalized cartesian coordinates
+// (see GetCartesian for the coordinate conventions)
+func CreateBoardPositionFromCartesian(x float64, y float64) BoardPosition {
+	polarRadius := math.Sqrt(x*x + y*y)
+	thetaAsDegrees := math.Atan2(x, y) * (180 / math.Pi)
+	return BoardPosition{
+		Radius: polarRadius,
+		Angle:  thetaAsDegrees,
+	}
+}
+
 // CreateBoardPositionFromPolar creates a BoardPosition object from polar coordinates.
 // This is used when generating throws from random normal distributions, since those calculations
 // are done in polar coordinates.
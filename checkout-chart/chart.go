@@ -0,0 +1,103 @@
+package checkout_chart
+
+//	A printable checkout chart: for each score that can be finished in one visit, where to aim each dart and the
+//	chance of finishing, worked out for one player's accuracy rather than the generic chart of the professionals.
+//	The chart is described here independently of how it is drawn, so the same chart can be written as an HTML
+//	page, or an SVG or PNG image.  The scores run down the columns, lowest first.
+
+import (
+	"DStratMC/game"
+	"fmt"
+	"strings"
+)
+
+// Number of columns of scores on the chart
+const chartColumns = 4
+
+// Row is the advice for one remaining score
+type Row struct {
+	Score       int
+	Route       string // The aim of each dart, e.g. "T20 T19 D12"
+	Probability float64
+}
+
+// Chart is a complete checkout chart
+type Chart struct {
+	Title    string
+	Subtitle string
+	Rows     []Row
+}
+
+// NewCheckoutChart makes the chart of a checkout policy, for every score from the lowest that can be finished
+// to the highest, with a subtitle describing the player
+func NewCheckoutChart(policy game.CheckoutPolicy, playerDescription string) Chart {
+	rules := policy.GetRules()
+	chart := Chart{
+		Title: fmt.Sprintf("Checkout chart, %s out", game.FinishRuleDescription[rules.Finish]),
+	}
+	if policy.GetObjective() == game.CheckoutObjective_MostCheckouts {
+		chart.Subtitle = playerDescription + ", for the best chance of finishing in the visit"
+	} else {
+		chart.Subtitle = playerDescription + ", for the fewest darts to finish"
+	}
+	for remaining := 1; remaining <= game.HighestCheckout(policy); remaining++ {
+		route, found := game.GetCheckoutRoute(policy, remaining)
+		if !found {
+			continue
+		}
+		names := make([]string, 0, len(route.Aims))
+		for _, aim := range route.Aims {
+			names = append(names, aim.Name)
+		}
+		chart.Rows = append(chart.Rows, Row{
+			Score:       remaining,
+			Route:       strings.Join(names, " "),
+			Probability: route.CheckoutProbability,
+		})
+	}
+	return chart
+}
+
+// Columns divides the rows into the columns of the chart, as evenly as possible
+func (c Chart) Columns() [][]Row {
+	rowsPerColumn := (len(c.Rows) + chartColumns - 1) / chartColumns
+	columns := make([][]Row, 0, chartColumns)
+	for start := 0; start < len(c.Rows); start += rowsPerColumn {
+		columns = append(columns, c.Rows[start:min(start+rowsPerColumn, len(c.Rows))])
+	}
+	return columns
+}
+
+// probabilityText formats the chance of finishing for the chart, with a dash for none
+func probabilityText(probability float64) string {
+	if probability <= 0 {
+		return "-"
+	}
+	if probability < 0.01 {
+		return "<1%"
+	}
+	return fmt.Sprintf("%.0f%%", 100*probability)
+}
+
+// Layout of the chart as an image, in pixels
+const imageMargin = 20
+const imageHeaderHeight = 60
+const imageColumnWidth = 230
+const imageRowHeight = 16
+
+// Offsets of the score (right-aligned), aims, and chance (right-aligned) within a column
+const scoreRight = 32
+const routeLeft = 44
+const chanceRight = 210
+
+// ImageSize returns the width and height, in pixels, of the chart drawn as an image
+func (c Chart) ImageSize() (int, int) {
+	columns := c.Columns()
+	rowsPerColumn := 0
+	if len(columns) > 0 {
+		rowsPerColumn = len(columns[0])
+	}
+	width := 2*imageMargin + max(1, len(columns))*imageColumnWidth
+	height := imageHeaderHeight + (rowsPerColumn+1)*imageRowHeight + imageMargin
+	return width, height
+}
@@ -0,0 +1,41 @@
+package checkout_chart
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// htmlStyle lays the chart out for the screen, and to fit a single printed page
+const htmlStyle = `body { font-family: sans-serif; margin: 1em; }
+h1 { font-size: 1.4em; margin: 0; }
+p.subtitle { margin: 0.2em 0 1em 0; color: #555; }
+div.columns { display: flex; gap: 1.5em; align-items: flex-start; }
+table { border-collapse: collapse; font-size: 0.85em; }
+th, td { padding: 1px 6px; text-align: left; }
+th { border-bottom: 1px solid black; }
+td.score, td.chance { text-align: right; }
+tr:nth-child(even) td { background: #f0f0f0; }
+@media print { body { margin: 0; } @page { margin: 10mm; } }
+`
+
+// WriteHTML writes the chart as a stand-alone HTML page, ready to print
+func WriteHTML(w io.Writer, chart Chart) error {
+	var page strings.Builder
+	page.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&page, "<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n", html.EscapeString(chart.Title), htmlStyle)
+	fmt.Fprintf(&page, "<h1>%s</h1>\n<p class=\"subtitle\">%s</p>\n", html.EscapeString(chart.Title), html.EscapeString(chart.Subtitle))
+	page.WriteString("<div class=\"columns\">\n")
+	for _, column := range chart.Columns() {
+		page.WriteString("<table>\n<tr><th>Score</th><th>Aim</th><th>Chance</th></tr>\n")
+		for _, row := range column {
+			fmt.Fprintf(&page, "<tr><td class=\"score\">%d</td><td>%s</td><td class=\"chance\">%s</td></tr>\n",
+				row.Score, html.EscapeString(row.Route), html.EscapeString(probabilityText(row.Probability)))
+		}
+		page.WriteString("</table>\n")
+	}
+	page.WriteString("</div>\n</body>\n</html>\n")
+	_, err := io.WriteString(w, page.String())
+	return err
+}
@@ -0,0 +1,67 @@
+package checkout_chart
+
+import (
+	"fmt"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+var textColour = color.RGBA{A: 255}
+var subtitleColour = color.RGBA{R: 85, G: 85, B: 85, A: 255}
+var stripeColour = color.RGBA{R: 240, G: 240, B: 240, A: 255}
+
+// RenderPNG draws the chart as an image, sized to fit it.  It has the same layout as the SVG version, but uses a
+// small fixed bitmap font.
+func RenderPNG(chart Chart) *image.RGBA {
+	width, height := chart.ImageSize()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	drawText(img, chart.Title, imageMargin, imageMargin+10, textColour, false)
+	drawText(img, chart.Subtitle, imageMargin, imageMargin+30, subtitleColour, false)
+
+	for i, column := range chart.Columns() {
+		left := imageMargin + i*imageColumnWidth
+		top := imageHeaderHeight
+		drawText(img, "Score", left+scoreRight, top+12, textColour, true)
+		drawText(img, "Aim", left+routeLeft, top+12, textColour, false)
+		drawText(img, "Chance", left+chanceRight, top+12, textColour, true)
+		draw.Draw(img, image.Rect(left, top+imageRowHeight, left+chanceRight+4, top+imageRowHeight+1),
+			image.NewUniform(textColour), image.Point{}, draw.Src)
+		for j, row := range column {
+			y := top + (j+1)*imageRowHeight
+			if j%2 == 1 {
+				draw.Draw(img, image.Rect(left, y+1, left+chanceRight+4, y+1+imageRowHeight),
+					image.NewUniform(stripeColour), image.Point{}, draw.Src)
+			}
+			drawText(img, fmt.Sprint(row.Score), left+scoreRight, y+12, textColour, true)
+			drawText(img, row.Route, left+routeLeft, y+12, textColour, false)
+			drawText(img, probabilityText(row.Probability), left+chanceRight, y+12, textColour, true)
+		}
+	}
+	return img
+}
+
+// WritePNG writes the chart as a PNG image, sized to fit it
+func WritePNG(w io.Writer, chart Chart) error {
+	return png.Encode(w, RenderPNG(chart))
+}
+
+// drawText draws text with its baseline at y, starting at x or, if rightAligned, ending at x
+func drawText(img *image.RGBA, text string, x int, y int, colour color.RGBA, rightAligned bool) {
+	if rightAligned {
+		x -= font.MeasureString(basicfont.Face7x13, text).Round()
+	}
+	drawer := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(colour),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
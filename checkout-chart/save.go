@@ -0,0 +1,48 @@
+package checkout_chart
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnknownChartFormat is returned when a chart is to be saved to a file whose name ends in none of .html, .svg,
+// or .png
+var ErrUnknownChartFormat = errors.New("checkout chart file name must end in .html, .svg, or .png")
+
+// IsChartFile returns true if the file name has the extension of one of the formats a chart can be saved in
+func IsChartFile(filePath string) bool {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".html", ".htm", ".svg", ".png":
+		return true
+	}
+	return false
+}
+
+// SaveChart writes the chart to the given file, as HTML, SVG, or PNG according to the file's extension
+func SaveChart(filePath string, chart Chart) error {
+	if !IsChartFile(filePath) {
+		return ErrUnknownChartFormat
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("unable to create checkout chart file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".svg":
+		err = WriteSVG(file, chart)
+	case ".png":
+		err = WritePNG(file, chart)
+	default:
+		err = WriteHTML(file, chart)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write checkout chart: %w", err)
+	}
+	return nil
+}
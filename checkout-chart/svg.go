@@ -0,0 +1,42 @@
+package checkout_chart
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// WriteSVG writes the chart as an SVG image, sized to fit it
+func WriteSVG(w io.Writer, chart Chart) error {
+	width, height := chart.ImageSize()
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	fmt.Fprintf(&svg, `<text x="%d" y="%d" font-size="18">%s</text>`+"\n", imageMargin, imageMargin+10, html.EscapeString(chart.Title))
+	fmt.Fprintf(&svg, `<text x="%d" y="%d" fill="#555555">%s</text>`+"\n", imageMargin, imageMargin+30, html.EscapeString(chart.Subtitle))
+
+	for i, column := range chart.Columns() {
+		left := imageMargin + i*imageColumnWidth
+		top := imageHeaderHeight
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="end" font-weight="bold">Score</text>`+"\n", left+scoreRight, top+12)
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" font-weight="bold">Aim</text>`+"\n", left+routeLeft, top+12)
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="end" font-weight="bold">Chance</text>`+"\n", left+chanceRight, top+12)
+		fmt.Fprintf(&svg, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", left, top+imageRowHeight, left+chanceRight+4, top+imageRowHeight)
+		for j, row := range column {
+			y := top + (j+1)*imageRowHeight
+			if j%2 == 1 {
+				fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d" fill="#f0f0f0"/>`+"\n", left, y+1, chanceRight+4, imageRowHeight)
+			}
+			fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="end">%d</text>`+"\n", left+scoreRight, y+12, row.Score)
+			fmt.Fprintf(&svg, `<text x="%d" y="%d">%s</text>`+"\n", left+routeLeft, y+12, html.EscapeString(row.Route))
+			fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="end">%s</text>`+"\n", left+chanceRight, y+12,
+				html.EscapeString(probabilityText(row.Probability)))
+		}
+	}
+
+	svg.WriteString("</svg>\n")
+	_, err := io.WriteString(w, svg.String())
+	return err
+}
@@ -0,0 +1,64 @@
+package main
+
+//	The checkout chart mode of the command-line search: the best route to finish from each score, for the
+//	player's own accuracy, saved as a chart to print
+
+import (
+	checkout_chart "DStratMC/checkout-chart"
+	"DStratMC/game"
+	"DStratMC/simulation"
+	"fmt"
+)
+
+// Starting score of the legs the checkout chart is worked out for; only the scores that can be finished in one
+// visit appear on the chart
+const checkoutStartingScore = 501
+
+// validateCheckoutFlags checks the options for a checkout chart, returning the rules and objective they describe
+func validateCheckoutFlags(chartFile string, finish string, objective string) (game.X01Rules, game.CheckoutObjective, error) {
+	rules := game.NewX01Rules(checkoutStartingScore)
+	if !checkout_chart.IsChartFile(chartFile) {
+		return rules, game.CheckoutObjective_FewestDarts, checkout_chart.ErrUnknownChartFormat
+	}
+	finishRule, found := game.ParseFinishRule(finish)
+	if !found {
+		return rules, game.CheckoutObjective_FewestDarts, fmt.Errorf("finish must be double, master, or straight, got %q", finish)
+	}
+	rules.Finish = finishRule
+	switch objective {
+	case "darts":
+		return rules, game.CheckoutObjective_FewestDarts, nil
+	case "chance":
+		return rules, game.CheckoutObjective_MostCheckouts, nil
+	}
+	return rules, game.CheckoutObjective_FewestDarts, fmt.Errorf("checkout-for must be darts or chance, got %q", objective)
+}
+
+// runCheckoutChart works out the checkout policy for the model and saves its chart.  A seed other than 0 makes the
+// chart repeatable, for a model whose throws are sampled.
+func runCheckoutChart(model simulation.AccuracyModel,
+	rules game.X01Rules,
+	objective game.CheckoutObjective,
+	chartFile string,
+	playerDescription string,
+	seed uint64) error {
+	if seed != 0 {
+		model.SetSeed(seed)
+	}
+	policy, err := game.SolveCheckouts(model, rules, objective)
+	if err != nil {
+		return fmt.Errorf("checkout solver failed: %w", err)
+	}
+	return checkout_chart.SaveChart(chartFile, checkout_chart.NewCheckoutChart(policy, playerDescription))
+}
+
+// describePlayer describes the accuracy model set by the command-line options, for the subtitle of a chart
+func describePlayer(stdDev float64, wildFraction float64, realThrowsFile string) string {
+	if realThrowsFile != "" {
+		return "for the recorded throws in " + realThrowsFile
+	}
+	if wildFraction > 0 {
+		return fmt.Sprintf("for standard deviation %.3f with %.0f%% wild throws", stdDev, 100*wildFraction)
+	}
+	return fmt.Sprintf("for standard deviation %.3f", stdDev)
+}
@@ -0,0 +1,112 @@
+package main
+
+//	The cricket mode of the command-line search: the expected marks per dart on one cricket number, at the usual
+//	aim points and at the best points of a grid over the number's segment
+
+import (
+	"DStratMC/game"
+	"DStratMC/simulation"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// jsonMarksResult is the form in which the expected marks at one aim point are written as JSON
+type jsonMarksResult struct {
+	Name          string  `json:"name,omitempty"`
+	Description   string  `json:"description"`
+	Radius        float64 `json:"radius"`
+	Angle         float64 `json:"angle"`
+	ExpectedMarks float64 `json:"expectedMarks"`
+	StdError      float64 `json:"stdError"`
+}
+
+// jsonCricketOutput is the whole cricket report as JSON
+type jsonCricketOutput struct {
+	Number int               `json:"number"`
+	Aims   []jsonMarksResult `json:"aims"`
+	Best   []jsonMarksResult `json:"best"`
+}
+
+// validateCricketFlags checks the number asked for is one that counts in cricket
+func validateCricketFlags(number int) error {
+	for _, cricketNumber := range game.CricketNumbers {
+		if number == cricketNumber {
+			return nil
+		}
+	}
+	return fmt.Errorf("cricket must be 15 to 20, or 25 for the bull, got %d", number)
+}
+
+// runCricket works out the expected marks per dart on the number, at the usual aim points and over a grid, and
+// prints them, with the given number of the best grid points, in the requested format
+func runCricket(model simulation.AccuracyModel, number int, numThrows int, numResults int, seed uint64, format string) error {
+	if seed != 0 {
+		model.SetSeed(seed)
+	}
+	aims, err := game.CompareCricketAims(model, number, int32(numThrows))
+	if err != nil {
+		return fmt.Errorf("cricket search failed: %w", err)
+	}
+	best, err := game.SearchCricketMarks(context.Background(), model, number, int32(numThrows))
+	if err != nil {
+		return fmt.Errorf("cricket search failed: %w", err)
+	}
+	if numResults > 0 && numResults < len(best) {
+		best = best[:numResults]
+	}
+	if format == "json" {
+		return printCricketJson(number, aims, best, model)
+	}
+	printCricketTable(number, aims, best, model)
+	return nil
+}
+
+// printCricketTable writes the expected marks at the usual aim points, then at the best grid points, as plain text
+func printCricketTable(number int, aims []game.MarksResult, best []game.MarksResult, model simulation.AccuracyModel) {
+	fmt.Printf("Expected marks per dart on %s\n", cricketNumberName(number))
+	fmt.Printf("%-16s  %-32s  %8s  %8s\n", "Aim", "Target", "Marks", "StdErr")
+	for _, result := range aims {
+		fmt.Printf("%-16s  %-32s  %8.3f  %8.3f\n", result.Name, describeTarget(result.Position, model),
+			result.ExpectedMarks, result.StdError)
+	}
+	fmt.Println()
+	fmt.Printf("%4s  %-32s  %8s  %8s  %8s  %8s\n", "Rank", "Target", "Marks", "StdErr", "Radius", "Angle")
+	for i, result := range best {
+		fmt.Printf("%4d  %-32s  %8.3f  %8.3f  %8.3f  %8.2f\n", i+1, describeTarget(result.Position, model),
+			result.ExpectedMarks, result.StdError, result.Position.Radius, result.Position.Angle)
+	}
+}
+
+// printCricketJson writes the expected marks at the usual aim points, and at the best grid points, as JSON
+func printCricketJson(number int, aims []game.MarksResult, best []game.MarksResult, model simulation.AccuracyModel) error {
+	output := jsonCricketOutput{Number: number, Aims: jsonMarksResults(aims, model), Best: jsonMarksResults(best, model)}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// jsonMarksResults converts expected marks results to the form written as JSON
+func jsonMarksResults(results []game.MarksResult, model simulation.AccuracyModel) []jsonMarksResult {
+	output := make([]jsonMarksResult, 0, len(results))
+	for _, result := range results {
+		output = append(output, jsonMarksResult{
+			Name:          result.Name,
+			Description:   describeTarget(result.Position, model),
+			Radius:        result.Position.Radius,
+			Angle:         result.Position.Angle,
+			ExpectedMarks: result.ExpectedMarks,
+			StdError:      result.StdError,
+		})
+	}
+	return output
+}
+
+// cricketNumberName returns how a cricket number is spoken of, the bull by name
+func cricketNumberName(number int) string {
+	if number == 25 {
+		return "the bull"
+	}
+	return fmt.Sprintf("%d", number)
+}
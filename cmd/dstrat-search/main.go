@@ -0,0 +1,362 @@
+package main
+
+//	dstrat-search is a command-line version of the best-target search.  It runs the same
+//	multi-threaded Monte-Carlo search as the "Search Normal" mode of the user interface, but
+//	needs no display, so it can be scripted (e.g. for parameter sweeps on a build server).
+//
+//	Example:
+//		dstrat-search -stddev 0.1 -throws 5000 -workers 8 -format json
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/game"
+	"DStratMC/simulation"
+	target_search "DStratMC/target-search"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// jsonResult is the form in which one ranked result is written when JSON output is requested
+type jsonResult struct {
+	Rank        int     `json:"rank"`
+	Description string  `json:"description"`
+	Score       float64 `json:"averageScore"`
+	StdError    float64 `json:"stdError"`
+	Tied        bool    `json:"tiedWithBest"`
+	Radius      float64 `json:"radius"`
+	Angle       float64 `json:"angle"`
+}
+
+func main() {
+	stdDev := flag.Float64("stddev", 0.15, "standard deviation of the normal accuracy model, 0-1")
+	numThrows := flag.Int("throws", 5000, "number of throws at each target")
+	radiusStep := flag.Float64("radius-step", 0.03, "grid resolution: normalized radius step between target circles")
+	angleStep := flag.Float64("angle-step", 0.5, "grid resolution: degrees between targets on each circle")
+	numWorkers := flag.Int("workers", runtime.NumCPU(), "number of worker threads")
+	format := flag.String("format", "table", "output format: table or json")
+	numResults := flag.Int("top", 10, "number of ranked results to print (0 for all)")
+	biasX := flag.Float64("bias-x", 0, "systematic aim bias, normalized units, positive to the right")
+	biasY := flag.Float64("bias-y", 0, "systematic aim bias, normalized units, positive upwards")
+	wildFraction := flag.Float64("wild-fraction", 0, "fraction of wild throws, 0-1 (0 for a plain normal model)")
+	wildStdDev := flag.Float64("wild-stddev", 0.5, "standard deviation of wild throws, 0-1")
+	realThrowsFile := flag.String("real-throws", "", "file of real throws saved by the user interface; if given, its miss pattern is used instead of a normal model")
+	smooth := flag.Bool("smooth", false, "with -real-throws, smooth the recorded miss pattern with a kernel density estimate")
+	method := flag.String("method", "montecarlo", "scoring method: montecarlo, analytic for exact scores (normal and wild-throw models only), or grid for a whole-board score grid")
+	budget := flag.Int64("budget", 0, "if greater than 0, do an adaptive coarse-to-fine search with this total number of throws, instead of the grid search")
+	halving := flag.Bool("halving", false, "successive halving: start every target with -throws darts, then keep doubling the throws at the better half until the best is clear")
+	common := flag.Bool("common", false, "common random numbers: throw the same set of darts at every target, so targets are compared on equal luck")
+	seed := flag.Uint64("seed", 0, "seed for the random numbers, so the search can be repeated exactly (0 for a different search each run)")
+	chunkSize := flag.Int("chunk", 0, "targets handed to a worker at a time (0 to choose automatically)")
+	sweepFrom := flag.Float64("sweep-from", 0.02, "with -sweep-to, the smallest standard deviation of the sweep")
+	sweepTo := flag.Float64("sweep-to", 0, "if greater than 0, sweep the standard deviation up to this value, reporting the best target at each and where the best segment changes, instead of searching at -stddev")
+	sweepStep := flag.Float64("sweep-step", 0.01, "with -sweep-to, the step between standard deviations of the sweep")
+	chartFile := flag.String("chart", "", "with -sweep-to, also save a chart of expected score against standard deviation to this .svg or .png file")
+	checkoutFile := flag.String("checkout-chart", "", "if given, save a checkout chart, worked out for the accuracy model, to this .html, .svg, or .png file, instead of searching")
+	finish := flag.String("finish", "double", "with -checkout-chart, how a leg must finish: double, master (double or treble), or straight")
+	checkoutFor := flag.String("checkout-for", "darts", "with -checkout-chart, what to aim for: darts for the fewest darts to finish, or chance for the best chance of finishing in the visit")
+	opponentStdDev := flag.Float64("opponent-stddev", 0, "if greater than 0, simulate x01 legs against an opponent with this standard deviation, instead of searching")
+	opponentThrowsFile := flag.String("opponent-real-throws", "", "if given, simulate x01 legs against an opponent with the miss pattern of this file of real throws, instead of searching")
+	numLegs := flag.Int("legs", 10000, "with an opponent, the number of legs to simulate")
+	startingScore := flag.Int("start", 501, "with an opponent, the starting score of each leg")
+	doubleIn := flag.Bool("double-in", false, "with an opponent, a player's darts don't count until they hit a double")
+	policy := flag.String("policy", "own", "with an opponent, how the player chooses where to aim: own for the checkout policy worked out for them, or pro for that of a near-perfect player")
+	opponentPolicy := flag.String("opponent-policy", "own", "with an opponent, how the opponent chooses where to aim: own or pro")
+	cricket := flag.Int("cricket", 0, "if 15 to 20, or 25 for the bull, show the expected marks per dart on that cricket number at the usual aim points and the best points of its segment, instead of searching")
+	flag.Parse()
+
+	if err := validateFlags(*stdDev, *numThrows, *radiusStep, *angleStep, *numWorkers, *format,
+		*wildFraction, *wildStdDev, *method, *budget, *halving, *common, *chunkSize,
+		*checkoutFile, *cricket, *opponentStdDev > 0 || *opponentThrowsFile != "", *sweepTo); err != nil {
+		fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	model := simulation.NewNormalAccuracyModel(*stdDev)
+	if *wildFraction > 0 {
+		model = simulation.NewMixtureAccuracyModel(*stdDev, *wildStdDev, *wildFraction)
+	}
+	if *realThrowsFile != "" {
+		missVectors, err := loadMissVectors(*realThrowsFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			os.Exit(1)
+		}
+		model = simulation.NewEmpiricalAccuracyModel(missVectors, *smooth)
+	}
+	model.SetMeanOffset(*biasX, *biasY)
+	if *checkoutFile != "" {
+		rules, objective, err := validateCheckoutFlags(*checkoutFile, *finish, *checkoutFor)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		timeBeforeSolve := time.Now()
+		err = runCheckoutChart(model, rules, objective, *checkoutFile, describePlayer(*stdDev, *wildFraction, *realThrowsFile), *seed)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Checkout chart took %v\n", time.Since(timeBeforeSolve))
+		return
+	}
+	if *cricket != 0 {
+		if err := validateCricketFlags(*cricket); err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		timeBeforeSearch := time.Now()
+		if err := runCricket(model, *cricket, *numThrows, *numResults, *seed, *format); err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Cricket search took %v\n", time.Since(timeBeforeSearch))
+		return
+	}
+	if *opponentStdDev > 0 || *opponentThrowsFile != "" {
+		rules, objective, err := validateMatchFlags(*numLegs, *startingScore, *finish, *doubleIn, *policy, *opponentPolicy, *checkoutFor)
+		if err == nil && (*opponentStdDev < 0 || *opponentStdDev > 1) {
+			err = fmt.Errorf("opponent-stddev must be greater than 0 and at most 1, got %g", *opponentStdDev)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		opponentModel := simulation.NewNormalAccuracyModel(*opponentStdDev)
+		opponentDescription := fmt.Sprintf("Opponent (%.3f)", *opponentStdDev)
+		if *opponentThrowsFile != "" {
+			missVectors, err := loadMissVectors(*opponentThrowsFile)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+				os.Exit(1)
+			}
+			opponentModel = simulation.NewEmpiricalAccuracyModel(missVectors, *smooth)
+			opponentDescription = "Opponent (recorded)"
+		}
+		timeBeforeMatch := time.Now()
+		playerStrategy, err := matchStrategy(model, rules, objective, *policy, *seed)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			os.Exit(1)
+		}
+		opponentStrategy, err := matchStrategy(opponentModel, rules, objective, *opponentPolicy, *seed)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			os.Exit(1)
+		}
+		players := []game.MatchPlayer{
+			{Name: "You", Model: model, Strategy: playerStrategy},
+			{Name: opponentDescription, Model: opponentModel, Strategy: opponentStrategy},
+		}
+		if err := runMatch(players, rules, *numLegs, *seed, *format); err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Match took %v\n", time.Since(timeBeforeMatch))
+		return
+	}
+	if *sweepTo > 0 {
+		if err := validateSweepFlags(*sweepFrom, *sweepTo, *sweepStep, *chartFile); err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		timeBeforeSweep := time.Now()
+		if err := runSweep(model, target_search.SweepStdDevs(*sweepFrom, *sweepTo, *sweepStep), *numWorkers, *seed, *format, *chartFile, *stdDev); err != nil {
+			fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Sweep took %v\n", time.Since(timeBeforeSweep))
+		return
+	}
+	supplier := target_search.NewTargetSupplierWithIncrements(*radiusStep, *angleStep)
+	timeBeforeSearch := time.Now()
+	engine := target_search.NewSearchEngine(model, supplier, int32(*numThrows), *numWorkers)
+	if *budget > 0 {
+		engine = target_search.NewAdaptiveSearchEngine(model, *budget, *numWorkers)
+	}
+	if *halving {
+		engine = target_search.NewHalvingSearchEngine(model, supplier, int32(*numThrows), *numWorkers)
+	}
+	engine.SetCommonRandomNumbers(*common)
+	engine.SetChunkSize(*chunkSize)
+	if *seed != 0 {
+		engine.SetSeed(*seed)
+	}
+	switch *method {
+	case "analytic":
+		engine.SetScoringMethod(target_search.ScoringMethod_Analytic)
+	case "grid":
+		engine.SetScoringMethod(target_search.ScoringMethod_ScoreGrid)
+	}
+	results, err := engine.Run(context.Background(), nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dstrat-search: search failed:", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Search of %d targets took %v\n", results.GetNumResults(), time.Since(timeBeforeSearch))
+
+	ranked := target_search.MarkTiesWithBest(target_search.FilterToOneTargetEach(results.GetResultsSortedByHighScore()))
+	if *numResults > 0 && *numResults < len(ranked) {
+		ranked = ranked[:*numResults]
+	}
+	if *format == "json" {
+		err = printJson(ranked, model)
+	} else {
+		printTable(ranked, model)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dstrat-search:", err)
+		os.Exit(1)
+	}
+}
+
+// validateFlags checks the command-line values for sensible ranges before we start a long search, and that at most
+// one mode other than the search is asked for, without any options that only the search uses
+func validateFlags(stdDev float64, numThrows int, radiusStep float64, angleStep float64, numWorkers int, format string,
+	wildFraction float64, wildStdDev float64, method string, budget int64, halving bool, common bool, chunkSize int,
+	checkoutFile string, cricket int, opponent bool, sweepTo float64) error {
+	if stdDev <= 0 || stdDev > 1 {
+		return fmt.Errorf("stddev must be greater than 0 and at most 1, got %g", stdDev)
+	}
+	if numThrows < 1 {
+		return fmt.Errorf("throws must be at least 1, got %d", numThrows)
+	}
+	if radiusStep <= 0 || radiusStep > 1 {
+		return fmt.Errorf("radius-step must be greater than 0 and at most 1, got %g", radiusStep)
+	}
+	if angleStep <= 0 || angleStep > 360 {
+		return fmt.Errorf("angle-step must be greater than 0 and at most 360, got %g", angleStep)
+	}
+	if numWorkers < 1 {
+		return fmt.Errorf("workers must be at least 1, got %d", numWorkers)
+	}
+	if format != "table" && format != "json" {
+		return fmt.Errorf("format must be table or json, got %q", format)
+	}
+	if wildFraction < 0 || wildFraction > 1 {
+		return fmt.Errorf("wild-fraction must be 0 to 1, got %g", wildFraction)
+	}
+	if wildStdDev <= 0 || wildStdDev > 1 {
+		return fmt.Errorf("wild-stddev must be greater than 0 and at most 1, got %g", wildStdDev)
+	}
+	if method != "montecarlo" && method != "analytic" && method != "grid" {
+		return fmt.Errorf("method must be montecarlo, analytic, or grid, got %q", method)
+	}
+	if budget < 0 {
+		return fmt.Errorf("budget must be 0 or more, got %d", budget)
+	}
+	if halving && budget > 0 {
+		return fmt.Errorf("halving and budget can't be used together")
+	}
+
+	modes := make([]string, 0)
+	if checkoutFile != "" {
+		modes = append(modes, "checkout-chart")
+	}
+	if cricket != 0 {
+		modes = append(modes, "cricket")
+	}
+	if opponent {
+		modes = append(modes, "an opponent")
+	}
+	if sweepTo > 0 {
+		modes = append(modes, "sweep-to")
+	}
+	if len(modes) > 1 {
+		return fmt.Errorf("only one of checkout-chart, cricket, an opponent (opponent-stddev or opponent-real-throws), and sweep-to can be used at once, got %s",
+			strings.Join(modes, " and "))
+	}
+	if len(modes) == 1 {
+		searchOptions := make([]string, 0)
+		if budget > 0 {
+			searchOptions = append(searchOptions, "budget")
+		}
+		if halving {
+			searchOptions = append(searchOptions, "halving")
+		}
+		if common {
+			searchOptions = append(searchOptions, "common")
+		}
+		if method != "montecarlo" {
+			searchOptions = append(searchOptions, "method")
+		}
+		if chunkSize != 0 {
+			searchOptions = append(searchOptions, "chunk")
+		}
+		if len(searchOptions) > 0 {
+			return fmt.Errorf("the target search options %s can't be used with %s",
+				strings.Join(searchOptions, " and "), modes[0])
+		}
+	}
+	return nil
+}
+
+// loadMissVectors reads a file of real throws, as saved by the user interface, and returns their miss vectors
+func loadMissVectors(filePath string) ([]simulation.MissVector, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read real throws: %w", err)
+	}
+	realThrows := simulation.NewRealThrowCollectionInstance()
+	realThrows.LoadStoredJsonData(content)
+	if realThrows.GetNumThrows() == 0 {
+		return nil, fmt.Errorf("no throws found in %s", filePath)
+	}
+	return realThrows.GetMissVectors(), nil
+}
+
+// describeTarget names the board area of a target or, if the model has a systematic bias, where the darts
+// will be centred and how to adjust the aim for the bias
+func describeTarget(target boardgeo.BoardPosition, model simulation.AccuracyModel) string {
+	offsetX, offsetY := model.GetMeanOffset()
+	if offsetX != 0 || offsetY != 0 {
+		return target_search.DescribeAimCorrection(target, offsetX, offsetY)
+	}
+	_, _, description := boardgeo.DescribeBoardPoint(target)
+	return description
+}
+
+// printTable writes the ranked results as a plain text table.  Targets statistically tied with the best
+// are marked with an asterisk.
+func printTable(ranked []target_search.OneResult, model simulation.AccuracyModel) {
+	fmt.Printf("%4s  %-32s  %8s  %8s  %8s  %8s\n", "Rank", "Target", "Average", "StdErr", "Radius", "Angle")
+	for i, result := range ranked {
+		description := describeTarget(result.Position, model)
+		tieMarker := ""
+		if i > 0 && result.TiedWithBest {
+			tieMarker = " *"
+		}
+		fmt.Printf("%4d  %-32s  %8.3f  %8.3f  %8.3f  %8.2f%s\n",
+			i+1, description, result.Score, result.StdError(), result.Position.Radius, result.Position.Angle, tieMarker)
+	}
+}
+
+// printJson writes the ranked results as a JSON array
+func printJson(ranked []target_search.OneResult, model simulation.AccuracyModel) error {
+	output := make([]jsonResult, 0, len(ranked))
+	for i, result := range ranked {
+		description := describeTarget(result.Position, model)
+		output = append(output, jsonResult{
+			Rank:        i + 1,
+			Description: description,
+			Score:       result.Score,
+			StdError:    result.StdError(),
+			Tied:        result.TiedWithBest,
+			Radius:      result.Position.Radius,
+			Angle:       result.Position.Angle,
+		})
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
@@ -0,0 +1,126 @@
+package main
+
+//	The match mode of the command-line search: simulated x01 legs between the player described by the usual
+//	accuracy options and an opponent, each aiming by a checkout policy, with the statistics of each player
+
+import (
+	"DStratMC/game"
+	"DStratMC/simulation"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Standard deviation of the near-perfect player whose checkout policy stands in for the generic professional
+// chart, and the highest score on that chart; above it, such a player simply aims at treble 20
+const proPolicyStdDev = 0.02
+const proPolicyHighestScore = 170
+
+// jsonMatchPlayer is the form in which one player's statistics are written as JSON
+type jsonMatchPlayer struct {
+	Name               string  `json:"name"`
+	LegsWon            int     `json:"legsWon"`
+	LegWinProbability  float64 `json:"legWinProbability"`
+	DartsPerLegWon     float64 `json:"dartsPerLegWon"`
+	ThreeDartAverage   float64 `json:"threeDartAverage"`
+	CheckoutPercentage float64 `json:"checkoutPercentage"`
+}
+
+// validateMatchFlags checks the options for a match simulation, returning the rules they describe
+func validateMatchFlags(numLegs int, startingScore int, finish string, doubleIn bool, policy string, opponentPolicy string,
+	checkoutFor string) (game.X01Rules, game.CheckoutObjective, error) {
+	rules := game.NewX01Rules(startingScore)
+	rules.DoubleIn = doubleIn
+	if numLegs < 1 {
+		return rules, game.CheckoutObjective_FewestDarts, fmt.Errorf("legs must be at least 1, got %d", numLegs)
+	}
+	if startingScore < 2 || startingScore > 1001 {
+		return rules, game.CheckoutObjective_FewestDarts, fmt.Errorf("start must be 2 to 1001, got %d", startingScore)
+	}
+	for _, name := range []string{policy, opponentPolicy} {
+		if name != "own" && name != "pro" {
+			return rules, game.CheckoutObjective_FewestDarts, fmt.Errorf("policy must be own or pro, got %q", name)
+		}
+	}
+	finishRule, found := game.ParseFinishRule(finish)
+	if !found {
+		return rules, game.CheckoutObjective_FewestDarts, fmt.Errorf("finish must be double, master, or straight, got %q", finish)
+	}
+	rules.Finish = finishRule
+	switch checkoutFor {
+	case "darts":
+		return rules, game.CheckoutObjective_FewestDarts, nil
+	case "chance":
+		return rules, game.CheckoutObjective_MostCheckouts, nil
+	}
+	return rules, game.CheckoutObjective_FewestDarts, fmt.Errorf("checkout-for must be darts or chance, got %q", checkoutFor)
+}
+
+// matchStrategy returns the aim strategy for a player: the checkout policy worked out for their own model, or
+// the policy of a near-perfect player, which is much like the generic chart the professionals use.  A seed other
+// than 0 makes the policy repeatable, for a model whose throws are sampled.
+func matchStrategy(model simulation.AccuracyModel, rules game.X01Rules, objective game.CheckoutObjective, policyName string,
+	seed uint64) (game.AimStrategy, error) {
+	if policyName == "pro" {
+		model = simulation.NewNormalAccuracyModel(proPolicyStdDev)
+		rules.StartingScore = min(rules.StartingScore, proPolicyHighestScore)
+	}
+	solverModel := model.Clone()
+	if seed != 0 {
+		solverModel.SetSeed(seed)
+	}
+	policy, err := game.SolveCheckouts(solverModel, rules, objective)
+	if err != nil {
+		return nil, fmt.Errorf("checkout solver failed: %w", err)
+	}
+	return game.NewPolicyAimStrategy(policy), nil
+}
+
+// runMatch simulates the legs between the two players and prints their statistics in the requested format
+func runMatch(players []game.MatchPlayer, rules game.X01Rules, numLegs int, seed uint64, format string) error {
+	simulator, err := game.NewMatchSimulator(players, rules)
+	if err != nil {
+		return err
+	}
+	if seed != 0 {
+		simulator.SetSeed(seed)
+	}
+	stats, err := simulator.Run(context.Background(), numLegs, nil)
+	if err != nil {
+		return fmt.Errorf("match simulation failed: %w", err)
+	}
+	if format == "json" {
+		return printMatchJson(stats)
+	}
+	printMatchTable(stats)
+	return nil
+}
+
+// printMatchTable writes each player's statistics as a plain text table
+func printMatchTable(stats game.MatchStats) {
+	fmt.Printf("%d legs\n", stats.Legs)
+	fmt.Printf("%-24s  %8s  %8s  %8s  %8s  %8s\n", "Player", "Legs", "Win %", "Darts", "Average", "Checkout")
+	for _, player := range stats.Players {
+		fmt.Printf("%-24s  %8d  %8.1f  %8.2f  %8.2f  %7.1f%%\n", player.Name, player.LegsWon, 100*player.LegWinProbability,
+			player.DartsPerLegWon, player.ThreeDartAverage, player.CheckoutPercentage)
+	}
+}
+
+// printMatchJson writes each player's statistics as a JSON array
+func printMatchJson(stats game.MatchStats) error {
+	output := make([]jsonMatchPlayer, 0, len(stats.Players))
+	for _, player := range stats.Players {
+		output = append(output, jsonMatchPlayer{
+			Name:               player.Name,
+			LegsWon:            player.LegsWon,
+			LegWinProbability:  player.LegWinProbability,
+			DartsPerLegWon:     player.DartsPerLegWon,
+			ThreeDartAverage:   player.ThreeDartAverage,
+			CheckoutPercentage: player.CheckoutPercentage,
+		})
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
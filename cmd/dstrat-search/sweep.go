@@ -0,0 +1,137 @@
+package main
+
+//	The sigma sweep mode of the command-line search: the best target at each of a range of standard deviations,
+//	and the standard deviations at which the best segment changes
+
+import (
+	"DStratMC/simulation"
+	sweep_chart "DStratMC/sweep-chart"
+	target_search "DStratMC/target-search"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// jsonSweepPoint is the form in which the best target at one standard deviation is written as JSON
+type jsonSweepPoint struct {
+	StdDev      float64 `json:"stdDev"`
+	Description string  `json:"description"`
+	Segment     int     `json:"segment"`
+	Score       float64 `json:"expectedScore"`
+	Radius      float64 `json:"radius"`
+	Angle       float64 `json:"angle"`
+}
+
+// jsonCrossover is the form in which a crossover is written as JSON
+type jsonCrossover struct {
+	StdDev      float64 `json:"stdDev"`
+	FromSegment int     `json:"fromSegment"`
+	ToSegment   int     `json:"toSegment"`
+}
+
+// jsonSweep is the whole sweep, as written when JSON output is requested
+type jsonSweep struct {
+	Points     []jsonSweepPoint `json:"points"`
+	Crossovers []jsonCrossover  `json:"crossovers"`
+}
+
+// validateSweepFlags checks the range of standard deviations for a sweep
+func validateSweepFlags(from float64, to float64, step float64, chartFile string) error {
+	if from <= 0 || to > 1 || from >= to {
+		return fmt.Errorf("sweep-from and sweep-to must satisfy 0 < sweep-from < sweep-to <= 1, got %g and %g", from, to)
+	}
+	if step <= 0 || step > to-from {
+		return fmt.Errorf("sweep-step must be greater than 0 and at most sweep-to minus sweep-from, got %g", step)
+	}
+	if extension := strings.ToLower(filepath.Ext(chartFile)); chartFile != "" && extension != ".svg" && extension != ".png" {
+		return sweep_chart.ErrUnknownChartFormat
+	}
+	return nil
+}
+
+// runSweep does the sigma sweep and prints the results in the requested format.  If a chart file is given, the
+// score curves of the usual targets and the best target are charted there, with the player's standard deviation marked.
+// A seed other than 0 makes the sweep repeatable.
+func runSweep(model simulation.AccuracyModel, stdDevs []float64, numWorkers int, seed uint64, format string, chartFile string,
+	playerStdDev float64) error {
+	points, crossovers, err := target_search.SigmaSweep(context.Background(), model, stdDevs, numWorkers, seed)
+	if err != nil {
+		return fmt.Errorf("sweep failed: %w", err)
+	}
+	if chartFile != "" {
+		curves, err := target_search.SweepScoreCurves(model, points, target_search.DefaultChartTargets(), seed)
+		if err != nil {
+			return fmt.Errorf("chart failed: %w", err)
+		}
+		chart := sweep_chart.NewSweepChart(curves, crossovers, playerStdDev)
+		chart.XLabel = sweep_chart.StdDevAxisLabel(model)
+		if err := sweep_chart.SaveChart(chartFile, chart); err != nil {
+			return err
+		}
+	}
+	if format == "json" {
+		return printSweepJson(points, crossovers, model)
+	}
+	printSweepTable(points, crossovers, model)
+	return nil
+}
+
+// printSweepTable writes the best target at each standard deviation, then the crossovers, as plain text
+func printSweepTable(points []target_search.SigmaSweepPoint, crossovers []target_search.SigmaCrossover, model simulation.AccuracyModel) {
+	fmt.Printf("%8s  %-32s  %8s  %8s  %8s\n", "StdDev", "Best Target", "Expected", "Radius", "Angle")
+	for _, point := range points {
+		fmt.Printf("%8.3f  %-32s  %8.3f  %8.3f  %8.2f\n", point.StdDev, describeTarget(point.Best.Position, model),
+			point.Best.Score, point.Best.Position.Radius, point.Best.Position.Angle)
+	}
+	fmt.Println()
+	if len(crossovers) == 0 {
+		fmt.Println("The best segment is the same throughout")
+	}
+	for _, crossover := range crossovers {
+		fmt.Printf("At stddev %.3f the best segment changes from %s to %s\n",
+			crossover.StdDev, segmentName(crossover.FromSegment), segmentName(crossover.ToSegment))
+	}
+}
+
+// printSweepJson writes the sweep as a JSON object
+func printSweepJson(points []target_search.SigmaSweepPoint, crossovers []target_search.SigmaCrossover, model simulation.AccuracyModel) error {
+	output := jsonSweep{
+		Points:     make([]jsonSweepPoint, 0, len(points)),
+		Crossovers: make([]jsonCrossover, 0, len(crossovers)),
+	}
+	for _, point := range points {
+		output.Points = append(output.Points, jsonSweepPoint{
+			StdDev:      point.StdDev,
+			Description: describeTarget(point.Best.Position, model),
+			Segment:     point.Segment,
+			Score:       point.Best.Score,
+			Radius:      point.Best.Position.Radius,
+			Angle:       point.Best.Position.Angle,
+		})
+	}
+	for _, crossover := range crossovers {
+		output.Crossovers = append(output.Crossovers, jsonCrossover{
+			StdDev:      crossover.StdDev,
+			FromSegment: crossover.FromSegment,
+			ToSegment:   crossover.ToSegment,
+		})
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// segmentName names a segment as returned by target_search.SegmentOf
+func segmentName(segment int) string {
+	switch segment {
+	case 0:
+		return "off the board"
+	case 25:
+		return "the bull"
+	}
+	return strconv.Itoa(segment)
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	boardgeo "DStratMC/board-geometry"
+)
+
+// AimStrategy decides where a player aims each dart of an x01 leg
+type AimStrategy interface {
+	ChooseAim(remaining int, dartsLeft int, visitStartScore int, opened bool) boardgeo.BoardPosition
+}
+
+// PolicyAimStrategyInstance aims as a checkout policy advises
+type PolicyAimStrategyInstance struct {
+	policy      CheckoutPolicy
+	scoringAim  boardgeo.BoardPosition
+	doubleInAim boardgeo.BoardPosition
+}
+
+// NewPolicyAimStrategy creates a strategy that follows the given checkout policy.  The policy may have been
+// worked out for a different player, for example the chart of a much better player followed by a weaker one.
+// Where the policy has no advice - a score above its starting score, or before opening with double in - the
+// player aims at treble 20, or double 20 to open.
+func NewPolicyAimStrategy(policy CheckoutPolicy) AimStrategy {
+	instance := &PolicyAimStrategyInstance{
+		policy:      policy,
+		scoringAim:  regionCentre(boardgeo.BoardArea_Treble, 20),
+		doubleInAim: regionCentre(boardgeo.BoardArea_Double, 20),
+	}
+	return instance
+}
+
+// ChooseAim returns where to aim with the given score remaining and darts left in the visit, which started with
+// visitStartScore remaining, and whether the player's darts count yet
+func (s *PolicyAimStrategyInstance) ChooseAim(remaining int, dartsLeft int, visitStartScore int, opened bool) boardgeo.BoardPosition {
+	if !opened {
+		return s.doubleInAim
+	}
+	//	A visit started above the policy's starting score is treated as started at it, as the nearest it knows
+	visitStartScore = min(visitStartScore, s.policy.GetRules().StartingScore)
+	if entry, found := s.policy.Lookup(remaining, dartsLeft, visitStartScore); found {
+		return entry.Aim.Position
+	}
+	return s.scoringAim
+}
+
+// regionCentre returns the centre of the area of the given segment, for example the treble 20
+func regionCentre(area boardgeo.BoardArea, segment int) boardgeo.BoardPosition {
+	for _, aim := range checkoutAims() {
+		if aim.Dart.Area == area && aim.Dart.Segment == segment {
+			return aim.Position
+		}
+	}
+	return boardgeo.CreateBoardPositionFromPolar(0, 0)
+}
@@ -0,0 +1,48 @@
+package game
+
+// CheckoutRoute is the advice for a visit started with a given score: where to aim each dart, supposing each
+// lands where it was aimed, and the chance of finishing in the visit
+type CheckoutRoute struct {
+	Remaining           int
+	Aims                []CheckoutAim
+	CheckoutProbability float64
+	ExpectedDarts       float64 // Average darts to finish the leg; NaN unless the policy is for the fewest darts
+}
+
+// GetCheckoutRoute returns the route for a visit started with the given score remaining, following the policy
+// dart by dart.  The route ends early if a dart aimed as advised would finish the leg.
+func GetCheckoutRoute(policy CheckoutPolicy, remaining int) (CheckoutRoute, bool) {
+	first, found := policy.Lookup(remaining, DartsPerVisit, remaining)
+	if !found {
+		return CheckoutRoute{}, false
+	}
+	route := CheckoutRoute{
+		Remaining:           remaining,
+		CheckoutProbability: first.CheckoutProbability,
+		ExpectedDarts:       policy.GetExpectedDarts(remaining),
+	}
+	score := remaining
+	for dartsLeft := DartsPerVisit; dartsLeft > 0; dartsLeft-- {
+		entry, found := policy.Lookup(score, dartsLeft, remaining)
+		if !found {
+			break
+		}
+		route.Aims = append(route.Aims, entry.Aim)
+		outcome, after, _ := policy.GetRules().ApplyDart(score, true, entry.Aim.Dart)
+		if outcome != DartOutcome_Scored {
+			break
+		}
+		score = after
+	}
+	return route, true
+}
+
+// HighestCheckout returns the highest score that the policy gives any chance of finishing in one visit
+func HighestCheckout(policy CheckoutPolicy) int {
+	for remaining := policy.GetRules().StartingScore; remaining > 0; remaining-- {
+		if entry, found := policy.Lookup(remaining, DartsPerVisit, remaining); found && entry.CheckoutProbability > 0 {
+			return remaining
+		}
+	}
+	return 0
+}
@@ -0,0 +1,375 @@
+package game
+
+//	The checkout solver works out where a player should aim at every stage of an x01 leg, for their own accuracy.
+//	For each aim point it finds the chance of the dart scoring each possible way - exactly for a Gaussian model,
+//	by sampling throws otherwise - and then, by dynamic programming from the lowest scores up, the best aim for
+//	each remaining score and number of darts left in the visit.
+//
+//	The usual objective is to finish in the fewest darts on average.  A bust sends the score back to where it
+//	was at the start of the visit, so the best aim can depend on that score too, and the value of a visit
+//	started at a score depends on itself (through busts, and through visits that score nothing).  For each
+//	visit-start score, that value is the fixed point of one pass over the states of the visit, found by Newton's
+//	method on the pass with the best aims held fixed - which, as the pass is piecewise linear in the value,
+//	takes only a few passes.
+//	The alternative objective is the greatest chance of finishing within the current visit, where a bust is
+//	simply a failure, so the start of the visit doesn't matter.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	target_search "DStratMC/target-search"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CheckoutObjective is what the checkout solver optimises
+type CheckoutObjective int
+
+const (
+	CheckoutObjective_FewestDarts   CheckoutObjective = iota // Fewest darts, on average, to finish the leg
+	CheckoutObjective_MostCheckouts                          // Greatest chance of finishing in this visit
+)
+
+// Number of throws sampled at each aim point for a model whose distribution has no formula
+const checkoutSampleThrows = 20_000
+
+// Outcomes less likely than this are ignored, which speeds the solver up greatly for accurate players
+const checkoutMinOutcomeProbability = 1e-7
+
+// Most points that can be scored in the darts of one visit after the first, so the furthest a state in the visit
+// can be from the score at the start of it
+const maxVisitOffset = (DartsPerVisit - 1) * 60
+
+// Newton's method stops when the expected darts from the start of a visit changes by less than this
+const checkoutTolerance = 1e-9
+const checkoutMaxIterations = 50
+
+var ErrStartingScoreTooHigh = errors.New("the checkout solver handles starting scores up to 1001")
+
+// CheckoutAim is one of the points the solver considers aiming at: the centre of a scoring region
+type CheckoutAim struct {
+	Name     string // e.g. "T20", "D16", "S5", or "Bull"
+	Position boardgeo.BoardPosition
+	Dart     Dart // What the dart scores if it lands where it was aimed
+}
+
+// CheckoutEntry is the solver's advice for one state of the game
+type CheckoutEntry struct {
+	Aim                 CheckoutAim
+	CheckoutProbability float64 // Chance of finishing in the current visit, aiming as advised
+	ExpectedDarts       float64 // Average darts still to throw to finish the leg; only for the fewest-darts objective
+}
+
+// CheckoutPolicy is the solver's advice for every state of a leg, for one player
+type CheckoutPolicy interface {
+	GetRules() X01Rules
+	GetObjective() CheckoutObjective
+	Lookup(remaining int, dartsLeft int, visitStartScore int) (CheckoutEntry, bool)
+	GetExpectedDarts(remaining int) float64
+}
+
+// CheckoutPolicyInstance is the table of advice worked out by the solver
+type CheckoutPolicyInstance struct {
+	rules     X01Rules
+	objective CheckoutObjective
+	lowest    int // The lowest score that can be finished
+	aims      []CheckoutAim
+	//	Indexed by darts left (1 to 3), remaining score, and how far the remaining score is below the score at the
+	//	start of the visit.  With 3 darts left they are the same, so only the first of the last index is used.
+	entries [DartsPerVisit + 1][][]policyEntry
+}
+
+type policyEntry struct {
+	solved        bool
+	aim           int
+	probability   float64
+	expectedDarts float64
+}
+
+// weightedDart is one way a dart aimed at a point may score, and how likely it is
+type weightedDart struct {
+	dart        Dart
+	probability float64
+	finishing   bool // The dart may finish a leg under the rules
+}
+
+// SolveCheckouts works out the best aim at every remaining score of a leg under the given rules, from the
+// starting score down, for a player with the given accuracy model.  Players are assumed to have opened their
+// scoring already, so double in is ignored.  The model is used to sample throws if it isn't Gaussian, so its
+// random numbers are consumed.
+func SolveCheckouts(model simulation.AccuracyModel, rules X01Rules, objective CheckoutObjective) (CheckoutPolicy, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if rules.StartingScore > 1001 {
+		return nil, ErrStartingScoreTooHigh
+	}
+	aims := checkoutAims()
+	outcomes := make([][]weightedDart, len(aims))
+	for i, aim := range aims {
+		aimOutcomes, err := outcomeDistribution(aim.Position, model)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find scores at %s: %w", aim.Name, err)
+		}
+		for j := range aimOutcomes {
+			aimOutcomes[j].finishing = rules.IsFinishingDart(aimOutcomes[j].dart)
+		}
+		outcomes[i] = aimOutcomes
+	}
+
+	policy := &CheckoutPolicyInstance{
+		rules:     rules,
+		objective: objective,
+		lowest:    rules.lowestFinish(),
+		aims:      aims,
+	}
+	for dartsLeft := 1; dartsLeft <= DartsPerVisit; dartsLeft++ {
+		policy.entries[dartsLeft] = make([][]policyEntry, rules.StartingScore+1)
+		for remaining := range policy.entries[dartsLeft] {
+			policy.entries[dartsLeft][remaining] = make([]policyEntry, maxVisitOffset+1)
+		}
+	}
+	if objective == CheckoutObjective_MostCheckouts {
+		policy.solveMostCheckouts(outcomes)
+	} else {
+		policy.solveFewestDarts(outcomes)
+	}
+	return policy, nil
+}
+
+// solveMostCheckouts fills the table with the aims giving the greatest chance of finishing in the visit.  A score
+// that can't be finished with the darts left has no chance whatever the aim, so its aim means nothing.
+func (p *CheckoutPolicyInstance) solveMostCheckouts(outcomes [][]weightedDart) {
+	for dartsLeft := 1; dartsLeft <= DartsPerVisit; dartsLeft++ {
+		for remaining := p.lowest; remaining <= p.rules.StartingScore; remaining++ {
+			best := policyEntry{solved: true, aim: -1, expectedDarts: math.NaN()}
+			for aim, aimOutcomes := range outcomes {
+				probability := 0.0
+				for _, outcome := range aimOutcomes {
+					result, after := p.applyDart(remaining, outcome)
+					switch {
+					case result == DartOutcome_Checkout:
+						probability += outcome.probability
+					case result == DartOutcome_Scored && dartsLeft > 1:
+						probability += outcome.probability * p.entries[dartsLeft-1][after][0].probability
+					}
+				}
+				if best.aim < 0 || probability > best.probability {
+					best.aim, best.probability = aim, probability
+				}
+			}
+			p.entries[dartsLeft][remaining][0] = best
+		}
+	}
+}
+
+// solveFewestDarts fills the table with the aims that finish the leg in the fewest darts on average, working up
+// from the lowest visit-start score, as every visit either busts or leaves a lower score for the next
+func (p *CheckoutPolicyInstance) solveFewestDarts(outcomes [][]weightedDart) {
+	lowest := p.lowest
+	for visitStart := lowest; visitStart <= p.rules.StartingScore; visitStart++ {
+		//	Start from the value of the score below, which is about right, and improve it by Newton's method
+		startValue := float64(DartsPerVisit)
+		if visitStart > lowest {
+			startValue = p.entries[DartsPerVisit][visitStart-1][0].expectedDarts
+		}
+		for iteration := 1; ; iteration++ {
+			value, slope := p.visitPass(outcomes, visitStart, startValue)
+			if slope >= 1 {
+				//	Every visit comes back to the same score: this player can never finish from here
+				p.entries[DartsPerVisit][visitStart][0].expectedDarts = math.Inf(1)
+				break
+			}
+			next := (value - slope*startValue) / (1 - slope)
+			if math.Abs(next-startValue) < checkoutTolerance || iteration == checkoutMaxIterations {
+				break
+			}
+			startValue = next
+		}
+	}
+}
+
+// visitPass works out, for a visit started at visitStart, the best aim and its expected darts at every state of
+// the visit, supposing that a visit started at that score takes startValue darts on average to finish.  It
+// returns the expected darts from the start of the visit, and how fast that changes with startValue.
+func (p *CheckoutPolicyInstance) visitPass(outcomes [][]weightedDart, visitStart int, startValue float64) (float64, float64) {
+	//	Rate of change of each state's expected darts with startValue, for the dart before it
+	var slopes [DartsPerVisit + 1][maxVisitOffset + 1]float64
+	for dartsLeft := 1; dartsLeft <= DartsPerVisit; dartsLeft++ {
+		maxOffset := (DartsPerVisit - dartsLeft) * 60
+		for offset := 0; offset <= maxOffset && offset <= visitStart-p.lowest; offset++ {
+			remaining := visitStart - offset
+			//	Part way through a visit, with a score too high to bust in the darts left, the state doesn't depend
+			//	on the score at the start of the visit, so is the same as it was for the visit started one higher
+			if offset > 1 && remaining >= 60*dartsLeft+p.lowest {
+				p.entries[dartsLeft][remaining][offset] = p.entries[dartsLeft][remaining][1]
+				continue
+			}
+			best := policyEntry{solved: true, aim: -1}
+			bestSlope := 0.0
+			for aim, aimOutcomes := range outcomes {
+				expected, slope, probability := 1.0, 0.0, 0.0
+				for _, outcome := range aimOutcomes {
+					result, after := p.applyDart(remaining, outcome)
+					switch {
+					case result == DartOutcome_Checkout:
+						probability += outcome.probability
+					case result == DartOutcome_Bust:
+						expected += outcome.probability * startValue
+						slope += outcome.probability
+					case dartsLeft > 1:
+						next := p.entries[dartsLeft-1][after][visitStart-after]
+						expected += outcome.probability * next.expectedDarts
+						slope += outcome.probability * slopes[dartsLeft-1][visitStart-after]
+						probability += outcome.probability * next.probability
+					case after == visitStart:
+						expected += outcome.probability * startValue
+						slope += outcome.probability
+					default:
+						expected += outcome.probability * p.entries[DartsPerVisit][after][0].expectedDarts
+					}
+				}
+				if best.aim < 0 || expected < best.expectedDarts {
+					best = policyEntry{solved: true, aim: aim, probability: probability, expectedDarts: expected}
+					bestSlope = slope
+				}
+			}
+			p.entries[dartsLeft][remaining][offset] = best
+			slopes[dartsLeft][offset] = bestSlope
+		}
+	}
+	return p.entries[DartsPerVisit][visitStart][0].expectedDarts, slopes[DartsPerVisit][0]
+}
+
+// applyDart is the rules' ApplyDart for a player who has opened, made quicker, as it is the heart of the solver,
+// by knowing already whether the dart may finish a leg
+func (p *CheckoutPolicyInstance) applyDart(remaining int, outcome weightedDart) (DartOutcome, int) {
+	after := remaining - outcome.dart.Score
+	switch {
+	case after == 0 && outcome.finishing:
+		return DartOutcome_Checkout, 0
+	case after < p.lowest:
+		return DartOutcome_Bust, remaining
+	}
+	return DartOutcome_Scored, after
+}
+
+// GetRules returns the rules the policy was worked out for
+func (p *CheckoutPolicyInstance) GetRules() X01Rules {
+	return p.rules
+}
+
+// GetObjective returns what the policy optimises
+func (p *CheckoutPolicyInstance) GetObjective() CheckoutObjective {
+	return p.objective
+}
+
+// Lookup returns the advice for a player with the given score remaining and darts left in the visit, who had
+// visitStartScore at the start of the visit.  It returns false if there is no such state in the table.
+func (p *CheckoutPolicyInstance) Lookup(remaining int, dartsLeft int, visitStartScore int) (CheckoutEntry, bool) {
+	offset := visitStartScore - remaining
+	if p.objective == CheckoutObjective_MostCheckouts || dartsLeft == DartsPerVisit {
+		offset = 0
+	}
+	if dartsLeft < 1 || dartsLeft > DartsPerVisit || remaining < p.lowest ||
+		remaining > p.rules.StartingScore || offset < 0 || offset > maxVisitOffset {
+		return CheckoutEntry{}, false
+	}
+	entry := p.entries[dartsLeft][remaining][offset]
+	if !entry.solved {
+		//	A state that can't be reached in a visit from that start, so was never worked out
+		return CheckoutEntry{}, false
+	}
+	return CheckoutEntry{
+		Aim:                 p.aims[entry.aim],
+		CheckoutProbability: entry.probability,
+		ExpectedDarts:       entry.expectedDarts,
+	}, true
+}
+
+// GetExpectedDarts returns the average number of darts to finish from the start of a visit with the given score
+// remaining, or NaN if the policy wasn't worked out for the fewest darts
+func (p *CheckoutPolicyInstance) GetExpectedDarts(remaining int) float64 {
+	if p.objective != CheckoutObjective_FewestDarts || remaining < p.lowest ||
+		remaining > p.rules.StartingScore {
+		return math.NaN()
+	}
+	return p.entries[DartsPerVisit][remaining][0].expectedDarts
+}
+
+// checkoutAims returns the points the solver considers aiming at: the bull, and the centre of every treble,
+// double, and single area.  The inner and outer singles of a segment score alike, so are named alike.
+func checkoutAims() []CheckoutAim {
+	bull := boardgeo.CreateBoardPositionFromPolar(0, 0)
+	aims := []CheckoutAim{{Name: "Bull", Position: bull, Dart: NewDart(bull)}}
+	prefixes := map[boardgeo.BoardArea]string{
+		boardgeo.BoardArea_Treble:      "T",
+		boardgeo.BoardArea_Double:      "D",
+		boardgeo.BoardArea_OuterSingle: "S",
+		boardgeo.BoardArea_InnerSingle: "S",
+	}
+	for _, region := range boardgeo.ScoringRegions() {
+		prefix, found := prefixes[region.Area]
+		if !found {
+			continue
+		}
+		angle := (region.StartAngle + region.EndAngle) / 2
+		if angle < 0 {
+			angle += 360
+		}
+		position := boardgeo.CreateBoardPositionFromPolar((region.InnerRadius+region.OuterRadius)/2, angle)
+		name := fmt.Sprintf("%s%d", prefix, region.SegmentValue)
+		aims = append(aims, CheckoutAim{Name: name, Position: position, Dart: NewDart(position)})
+	}
+	return aims
+}
+
+// outcomeDistribution returns the ways a dart aimed at the target may score, and their probabilities: exactly
+// for a Gaussian model, and from sampled throws otherwise.  Different areas with the same score and multiplier
+// (the inner and outer singles, or anywhere off the board) are combined.
+func outcomeDistribution(target boardgeo.BoardPosition, model simulation.AccuracyModel) ([]weightedDart, error) {
+	type scoreKey struct{ segment, multiplier int }
+	probabilities := make(map[scoreKey]float64)
+	darts := make(map[scoreKey]Dart)
+	add := func(dart Dart, probability float64) {
+		key := scoreKey{dart.Segment, dart.Multiplier}
+		probabilities[key] += probability
+		darts[key] = Dart{Area: dart.Area, Segment: dart.Segment, Multiplier: dart.Multiplier, Score: dart.Score}
+	}
+
+	if gaussianModel, isGaussian := model.(simulation.GaussianAccuracyModel); isGaussian {
+		regions := boardgeo.ScoringRegions()
+		onBoard := 0.0
+		for i, probability := range target_search.RegionProbabilities(target, gaussianModel) {
+			region := regions[i]
+			add(Dart{Area: region.Area, Segment: region.SegmentValue, Multiplier: region.Multiplier, Score: region.Score}, probability)
+			onBoard += probability
+		}
+		add(Dart{Area: boardgeo.BoardArea_Out}, math.Max(0, 1-onBoard))
+	} else {
+		for i := 0; i < checkoutSampleThrows; i++ {
+			position, err := model.GetThrow(target)
+			if err != nil {
+				return nil, err
+			}
+			add(NewDart(position), 1.0/checkoutSampleThrows)
+		}
+	}
+
+	//	In a fixed order, so that the sums, and so the choice between equally good aims, are the same every time
+	outcomes := make([]weightedDart, 0, len(probabilities))
+	for key, probability := range probabilities {
+		if probability >= checkoutMinOutcomeProbability {
+			outcomes = append(outcomes, weightedDart{dart: darts[key], probability: probability})
+		}
+	}
+	sort.Slice(outcomes, func(i, j int) bool {
+		if outcomes[i].dart.Segment != outcomes[j].dart.Segment {
+			return outcomes[i].dart.Segment < outcomes[j].dart.Segment
+		}
+		return outcomes[i].dart.Multiplier < outcomes[j].dart.Multiplier
+	})
+	return outcomes, nil
+}
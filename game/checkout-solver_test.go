@@ -0,0 +1,86 @@
+package game
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	target_search "DStratMC/target-search"
+	"math"
+	"testing"
+)
+
+// doubleOneChances returns, for a Gaussian model aiming at the middle of double 1, the chance of hitting it and
+// the chance of missing the board altogether - from a score of 2, the only darts that don't bust
+func doubleOneChances(t *testing.T, model simulation.GaussianAccuracyModel) (float64, float64) {
+	probabilities := target_search.RegionProbabilities(regionCentre(boardgeo.BoardArea_Double, 1), model)
+	hit, onBoard := 0.0, 0.0
+	for i, region := range boardgeo.ScoringRegions() {
+		if region.SegmentValue == 1 && region.Multiplier == 2 {
+			hit = probabilities[i]
+		}
+		onBoard += probabilities[i]
+	}
+	if hit == 0 {
+		t.Fatal("no chance of hitting double 1")
+	}
+	return hit, 1 - onBoard
+}
+
+// TestCheckoutFromTwo checks the solver on a score of 2, where the chances can be worked out by hand: each dart
+// at double 1 finishes with chance p, misses the board and leaves 2 with chance q, and otherwise busts
+func TestCheckoutFromTwo(t *testing.T) {
+	for _, stdDev := range []float64{0.02, 0.05, 0.1} {
+		model := simulation.NewNormalAccuracyModel(stdDev)
+		p, q := doubleOneChances(t, model.(simulation.GaussianAccuracyModel))
+		r := 1 - p - q
+
+		chancePolicy, err := SolveCheckouts(model, NewX01Rules(40), CheckoutObjective_MostCheckouts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantChance := []float64{0, p, p * (1 + q), p * (1 + q + q*q)}
+		for dartsLeft := 1; dartsLeft <= DartsPerVisit; dartsLeft++ {
+			entry, found := chancePolicy.Lookup(2, dartsLeft, 2)
+			if !found {
+				t.Fatalf("stddev %g: no advice for 2 with %d darts", stdDev, dartsLeft)
+			}
+			if entry.Aim.Name != "D1" || math.Abs(entry.CheckoutProbability-wantChance[dartsLeft]) > 1e-9 {
+				t.Errorf("stddev %g, %d darts: got %s with chance %.9f, want D1 with %.9f", stdDev, dartsLeft,
+					entry.Aim.Name, entry.CheckoutProbability, wantChance[dartsLeft])
+			}
+		}
+
+		//	From the start of a visit E = 1 + q E2 + r E, with E2 = 1 + q E1 + r E and E1 = 1 + (1 - p) E
+		wantDarts := (1 + q + q*q) / (1 - r - q*r - q*q*(1-p))
+		dartsPolicy, err := SolveCheckouts(model, NewX01Rules(40), CheckoutObjective_FewestDarts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := dartsPolicy.GetExpectedDarts(2); math.Abs(got-wantDarts) > 1e-6*wantDarts {
+			t.Errorf("stddev %g: got %.6f darts expected from 2, want %.6f", stdDev, got, wantDarts)
+		}
+	}
+}
+
+// TestCheckoutNearPerfectPlayer checks that a nearly perfect player finishes 170 by treble 20, treble 20, bull,
+// can't finish 169, and finishes any double in one dart
+func TestCheckoutNearPerfectPlayer(t *testing.T) {
+	policy, err := SolveCheckouts(simulation.NewNormalAccuracyModel(0.001), NewX01Rules(170), CheckoutObjective_FewestDarts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if highest := HighestCheckout(policy); highest != 170 {
+		t.Errorf("got highest checkout %d, want 170", highest)
+	}
+	route, found := GetCheckoutRoute(policy, 170)
+	if !found || len(route.Aims) != 3 || route.Aims[0].Name != "T20" || route.Aims[1].Name != "T20" || route.Aims[2].Name != "Bull" {
+		t.Errorf("got route %+v for 170, want T20 T20 Bull", route.Aims)
+	}
+	if entry, found := policy.Lookup(169, DartsPerVisit, 169); !found || entry.CheckoutProbability > 1e-9 {
+		t.Errorf("got chance %g of finishing 169, want 0", entry.CheckoutProbability)
+	}
+	for _, remaining := range []int{2, 32, 40} {
+		if got := policy.GetExpectedDarts(remaining); math.Abs(got-1) > 1e-6 {
+			t.Errorf("got %g darts expected from %d, want 1", got, remaining)
+		}
+	}
+}
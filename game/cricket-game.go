@@ -0,0 +1,214 @@
+package game
+
+//	A game of cricket between one or more players, played dart by dart from where each dart lands on the board.
+//	Only the numbers 15 to 20 and the bull count.  A dart in one of them scores marks on it: one for a single,
+//	two for a double, three for a treble, and one for the outer bull or two for the inner.  Three marks close the
+//	number for the player.  Marks beyond that, on a number some opponent hasn't closed, score its value in
+//	points (25 for the bull): to the player in the usual game, or to each such opponent in the cut-throat variant.
+//	The game is won by the first player to close every number while having the most points - or, cut-throat,
+//	the fewest.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+)
+
+// CricketNumbers are the numbers that count in cricket, the bull being 25
+var CricketNumbers = []int{20, 19, 18, 17, 16, 15, 25}
+
+// Marks needed to close a number
+const marksToClose = 3
+
+// CricketGame is a game of cricket, standard or cut-throat
+type CricketGame interface {
+	Throw(position boardgeo.BoardPosition) (CricketThrowResult, error)
+	IsCutThroat() bool
+	GetNumPlayers() int
+	GetCurrentPlayer() int
+	GetDartsLeftInVisit() int
+	GetMarks(player int, number int) int
+	IsClosed(player int, number int) bool
+	GetPoints(player int) int
+	IsGameOver() bool
+	GetWinner() int
+}
+
+// CricketThrowResult is what happened when a dart was thrown
+type CricketThrowResult struct {
+	Player    int
+	Dart      Dart
+	Marks     int // Marks the dart made on its number, including any that scored points
+	Points    int // Points the dart scored, for the player or, cut-throat, for each opponent still open
+	VisitOver bool
+	GameWon   bool // The dart ended the game; in cut-throat, the winner may be another player (see GetWinner)
+}
+
+// CricketGameInstance is the state of a game in progress
+type CricketGameInstance struct {
+	cutThroat     bool
+	marks         []map[int]int
+	points        []int
+	currentPlayer int
+	dartsLeft     int
+	gameOver      bool
+	winner        int
+}
+
+// NewCricketGame creates a game between numPlayers players, cut-throat if requested, with player 0 to throw first
+func NewCricketGame(numPlayers int, cutThroat bool) (CricketGame, error) {
+	if numPlayers < 1 {
+		return nil, ErrNoPlayers
+	}
+	instance := &CricketGameInstance{
+		cutThroat: cutThroat,
+		marks:     make([]map[int]int, numPlayers),
+		points:    make([]int, numPlayers),
+		dartsLeft: DartsPerVisit,
+		winner:    -1,
+	}
+	for i := range instance.marks {
+		instance.marks[i] = make(map[int]int, len(CricketNumbers))
+	}
+	return instance, nil
+}
+
+// CricketMarks returns the number a dart counts for in cricket, and how many marks it makes there, or 0 marks if
+// it landed outside the numbers that count
+func CricketMarks(dart Dart) (int, int) {
+	for _, number := range CricketNumbers {
+		if dart.Segment == number {
+			return number, dart.Multiplier
+		}
+	}
+	return 0, 0
+}
+
+// Throw plays a dart, for the player whose turn it is, that landed at the given position, and returns what
+// happened.  Once the game is over, ErrMatchOver is returned.
+func (c *CricketGameInstance) Throw(position boardgeo.BoardPosition) (CricketThrowResult, error) {
+	if c.gameOver {
+		return CricketThrowResult{}, ErrMatchOver
+	}
+	player := c.currentPlayer
+	dart := NewDart(position)
+	number, marks := CricketMarks(dart)
+	result := CricketThrowResult{Player: player, Dart: dart, Marks: marks}
+
+	if marks > 0 {
+		closing := min(marks, marksToClose-min(marksToClose, c.marks[player][number]))
+		c.marks[player][number] += marks
+		if extra := marks - closing; extra > 0 {
+			result.Points = extra * number
+			c.scorePoints(player, number, result.Points)
+		}
+	}
+
+	c.dartsLeft--
+	if winner := c.findWinner(player); winner >= 0 {
+		result.GameWon = true
+		result.VisitOver = true
+		c.gameOver = true
+		c.winner = winner
+		return result, nil
+	}
+	if c.dartsLeft == 0 {
+		result.VisitOver = true
+		c.currentPlayer = (c.currentPlayer + 1) % len(c.points)
+		c.dartsLeft = DartsPerVisit
+	}
+	return result, nil
+}
+
+// scorePoints adds the points for extra marks on a number: to the player, if some opponent hasn't closed it, or,
+// cut-throat, to each opponent who hasn't
+func (c *CricketGameInstance) scorePoints(player int, number int, points int) {
+	for opponent := range c.points {
+		if opponent == player || c.IsClosed(opponent, number) {
+			continue
+		}
+		if c.cutThroat {
+			c.points[opponent] += points
+		} else {
+			c.points[player] += points
+			return
+		}
+	}
+}
+
+// findWinner returns the player who has won after a dart by the given player, or -1 if no one has.  The thrower is
+// checked first but, in cut-throat, points given to one opponent can leave another with the fewest, so every
+// player is checked.
+func (c *CricketGameInstance) findWinner(thrower int) int {
+	if c.hasWon(thrower) {
+		return thrower
+	}
+	for player := range c.points {
+		if player != thrower && c.hasWon(player) {
+			return player
+		}
+	}
+	return -1
+}
+
+// hasWon returns true if the player has closed every number and has the best score: the most points, or the
+// fewest in cut-throat, with a tie good enough
+func (c *CricketGameInstance) hasWon(player int) bool {
+	for _, number := range CricketNumbers {
+		if !c.IsClosed(player, number) {
+			return false
+		}
+	}
+	for opponent, points := range c.points {
+		if opponent == player {
+			continue
+		}
+		if (c.cutThroat && points < c.points[player]) || (!c.cutThroat && points > c.points[player]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsCutThroat returns true if points are given to opponents rather than scored
+func (c *CricketGameInstance) IsCutThroat() bool {
+	return c.cutThroat
+}
+
+// GetNumPlayers returns the number of players in the game
+func (c *CricketGameInstance) GetNumPlayers() int {
+	return len(c.points)
+}
+
+// GetCurrentPlayer returns the player whose turn it is to throw
+func (c *CricketGameInstance) GetCurrentPlayer() int {
+	return c.currentPlayer
+}
+
+// GetDartsLeftInVisit returns the number of darts the current player has left to throw in this visit
+func (c *CricketGameInstance) GetDartsLeftInVisit() int {
+	return c.dartsLeft
+}
+
+// GetMarks returns the marks the given player has made on a number, including those that scored points
+func (c *CricketGameInstance) GetMarks(player int, number int) int {
+	return c.marks[player][number]
+}
+
+// IsClosed returns true if the given player has closed a number
+func (c *CricketGameInstance) IsClosed(player int, number int) bool {
+	return c.marks[player][number] >= marksToClose
+}
+
+// GetPoints returns the given player's points
+func (c *CricketGameInstance) GetPoints(player int) int {
+	return c.points[player]
+}
+
+// IsGameOver returns true once a player has won
+func (c *CricketGameInstance) IsGameOver() bool {
+	return c.gameOver
+}
+
+// GetWinner returns the player who won the game, or -1 if it isn't over
+func (c *CricketGameInstance) GetWinner() int {
+	return c.winner
+}
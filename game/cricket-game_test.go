@@ -0,0 +1,78 @@
+package game
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"testing"
+)
+
+// TestCricketPointsAndClosing checks the marks and points of darts in the standard game and in cut-throat
+func TestCricketPointsAndClosing(t *testing.T) {
+	treble20 := regionCentre(boardgeo.BoardArea_Treble, 20)
+	tests := []struct {
+		name       string
+		cutThroat  bool
+		wantPoints [2][]int // Points of each player after each of player 0's darts at treble 20
+	}{
+		{"standard", false, [2][]int{{0, 60, 120}, {0, 0, 0}}},
+		{"cut-throat", true, [2][]int{{0, 0, 0}, {0, 60, 120}}},
+	}
+	for _, test := range tests {
+		game, err := NewCricketGame(2, test.cutThroat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for dart := 0; dart < DartsPerVisit; dart++ {
+			result, err := game.Throw(treble20)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Marks != 3 {
+				t.Errorf("%s dart %d: got %d marks, want 3", test.name, dart, result.Marks)
+			}
+			for player := 0; player < 2; player++ {
+				if got := game.GetPoints(player); got != test.wantPoints[player][dart] {
+					t.Errorf("%s dart %d: player %d has %d points, want %d", test.name, dart, player, got, test.wantPoints[player][dart])
+				}
+			}
+		}
+		if !game.IsClosed(0, 20) || game.IsClosed(1, 20) {
+			t.Errorf("%s: 20 should be closed for player 0 only", test.name)
+		}
+		if game.GetCurrentPlayer() != 1 {
+			t.Errorf("%s: got player %d to throw, want 1", test.name, game.GetCurrentPlayer())
+		}
+	}
+}
+
+// TestCricketCutThroatWinForAnotherPlayer checks that, in cut-throat, a dart that gives points to one opponent
+// ends the game at once if it leaves a third player, with every number closed, with the fewest points
+func TestCricketCutThroatWinForAnotherPlayer(t *testing.T) {
+	created, err := NewCricketGame(3, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := created.(*CricketGameInstance)
+	for _, number := range CricketNumbers {
+		game.marks[2][number] = marksToClose
+	}
+	game.marks[0][20] = marksToClose
+	game.points = []int{40, 0, 20}
+	if game.IsGameOver() {
+		t.Fatal("game over before the dart")
+	}
+
+	result, err := game.Throw(regionCentre(boardgeo.BoardArea_Treble, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := game.GetPoints(1); got != 60 {
+		t.Errorf("player 1 has %d points, want 60", got)
+	}
+	if !result.GameWon || !game.IsGameOver() || game.GetWinner() != 2 {
+		t.Errorf("got GameWon %v, game over %v, winner %d; want the game won by player 2",
+			result.GameWon, game.IsGameOver(), game.GetWinner())
+	}
+	if _, err := game.Throw(regionCentre(boardgeo.BoardArea_Treble, 20)); err != ErrMatchOver {
+		t.Errorf("got error %v after the game ended, want ErrMatchOver", err)
+	}
+}
@@ -0,0 +1,175 @@
+package game
+
+//	Where to aim in cricket depends on skill.  A treble is worth three marks, but is a small target; the "fat"
+//	outer single is worth one, but is much easier to hit, and a miss from the treble often lands in a single
+//	anyway.  Here the expected marks per dart on one number are found for a given accuracy model - exactly for a
+//	Gaussian model, by simulating throws otherwise - both at the usual aim points and over a grid of aim points
+//	across the number's segment, so a player can see which is better for them.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	target_search "DStratMC/target-search"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Spacing of the grid of aim points searched for a cricket number
+const cricketSearchRadiusStep = 0.01
+const cricketSearchAngleStep = 1.0
+
+// The grid covers the number's segment and this many degrees either side of it, as the best aim may lean towards
+// a neighbour (for the bull, the grid covers the whole bull and a little beyond)
+const cricketSearchAngleMargin = 9.0
+const cricketBullSearchRadius = 0.15
+
+var ErrNotCricketNumber = errors.New("cricket is played on 15 to 20 and the bull (25)")
+
+// MarksResult is the expected marks per dart on a cricket number when aiming at a point
+type MarksResult struct {
+	Name          string // The usual aim point, e.g. "Treble 20", or empty for a point of the grid
+	Position      boardgeo.BoardPosition
+	ExpectedMarks float64
+	StdError      float64 // Of a simulated average; 0 when exact
+}
+
+// ExpectedMarksAt returns the average marks per dart on the number when aiming at the target, and its standard
+// error: exact for a Gaussian model, and from the given number of simulated throws otherwise
+func ExpectedMarksAt(target boardgeo.BoardPosition, model simulation.AccuracyModel, number int, throws int32) (MarksResult, error) {
+	if gaussianModel, isGaussian := model.(simulation.GaussianAccuracyModel); isGaussian {
+		regions := boardgeo.ScoringRegions()
+		expected := 0.0
+		for i, probability := range target_search.RegionProbabilities(target, gaussianModel) {
+			if regions[i].SegmentValue == number {
+				expected += probability * float64(regions[i].Multiplier)
+			}
+		}
+		return MarksResult{Position: target, ExpectedMarks: expected}, nil
+	}
+	sum, sumOfSquares := 0.0, 0.0
+	for i := int32(0); i < throws; i++ {
+		position, err := model.GetThrow(target)
+		if err != nil {
+			return MarksResult{}, err
+		}
+		hitNumber, marks := CricketMarks(NewDart(position))
+		if hitNumber == number {
+			sum += float64(marks)
+			sumOfSquares += float64(marks * marks)
+		}
+	}
+	mean := sum / float64(throws)
+	stdError := 0.0
+	if throws > 1 {
+		variance := (sumOfSquares - float64(throws)*mean*mean) / float64(throws-1)
+		stdError = math.Sqrt(math.Max(0, variance) / float64(throws))
+	}
+	return MarksResult{Position: target, ExpectedMarks: mean, StdError: stdError}, nil
+}
+
+// CompareCricketAims returns the expected marks per dart on the number at each of the usual aim points - the
+// treble, the outer ("fat") and inner singles, and the double, or the centre of the bull - from best to worst
+func CompareCricketAims(model simulation.AccuracyModel, number int, throws int32) ([]MarksResult, error) {
+	aims, err := cricketAims(number)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]MarksResult, 0, len(aims))
+	for _, aim := range aims {
+		result, err := ExpectedMarksAt(aim.Position, model, number, throws)
+		if err != nil {
+			return nil, err
+		}
+		result.Name = aim.Name
+		results = append(results, result)
+	}
+	sortMarksResults(results)
+	return results, nil
+}
+
+// SearchCricketMarks returns the expected marks per dart on the number at every point of a grid over its segment,
+// from best to worst.  If the context is cancelled, or a throw fails, the search stops with the error.
+func SearchCricketMarks(ctx context.Context, model simulation.AccuracyModel, number int, throws int32) ([]MarksResult, error) {
+	if _, err := cricketAims(number); err != nil {
+		return nil, err
+	}
+	results := make([]MarksResult, 0)
+	for _, target := range cricketSearchTargets(number) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result, err := ExpectedMarksAt(target, model, number, throws)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	sortMarksResults(results)
+	return results, nil
+}
+
+// cricketAims returns the usual aim points for a cricket number
+func cricketAims(number int) ([]CheckoutAim, error) {
+	if number == 25 {
+		return []CheckoutAim{{Name: "Bull", Position: boardgeo.CreateBoardPositionFromPolar(0, 0)}}, nil
+	}
+	if number < 15 || number > 20 {
+		return nil, ErrNotCricketNumber
+	}
+	names := map[boardgeo.BoardArea]string{
+		boardgeo.BoardArea_Treble:      "Treble",
+		boardgeo.BoardArea_OuterSingle: "Outer Single",
+		boardgeo.BoardArea_InnerSingle: "Inner Single",
+		boardgeo.BoardArea_Double:      "Double",
+	}
+	aims := make([]CheckoutAim, 0, len(names))
+	for _, area := range []boardgeo.BoardArea{boardgeo.BoardArea_Treble, boardgeo.BoardArea_OuterSingle,
+		boardgeo.BoardArea_InnerSingle, boardgeo.BoardArea_Double} {
+		aims = append(aims, CheckoutAim{
+			Name:     fmt.Sprintf("%s %d", names[area], number),
+			Position: regionCentre(area, number),
+		})
+	}
+	return aims, nil
+}
+
+// cricketSearchTargets returns the grid of aim points searched for a number: across its segment and a margin to
+// either side, out to the edge of the scoring area, or over the bull and a little around it
+func cricketSearchTargets(number int) []boardgeo.BoardPosition {
+	targets := []boardgeo.BoardPosition{boardgeo.CreateBoardPositionFromPolar(0, 0)}
+	maxRadius := 1.0
+	centreAngle, halfWidth := 0.0, 180.0
+	if number == 25 {
+		maxRadius = cricketBullSearchRadius
+	} else {
+		centre := regionCentre(boardgeo.BoardArea_Treble, number)
+		centreAngle, halfWidth = centre.Angle, 9+cricketSearchAngleMargin
+	}
+	for radius := cricketSearchRadiusStep; radius <= maxRadius+1e-9; radius += cricketSearchRadiusStep {
+		for offset := -halfWidth; offset < halfWidth+1e-9; offset += cricketSearchAngleStep {
+			if number == 25 && offset >= halfWidth {
+				break
+			}
+			angle := math.Mod(centreAngle+offset+360, 360)
+			targets = append(targets, boardgeo.CreateBoardPositionFromPolar(radius, angle))
+		}
+	}
+	return targets
+}
+
+// sortMarksResults sorts results from the most expected marks to the fewest, breaking ties by position so the
+// order is the same every time
+func sortMarksResults(results []MarksResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].ExpectedMarks != results[j].ExpectedMarks {
+			return results[i].ExpectedMarks > results[j].ExpectedMarks
+		}
+		if results[i].Position.Radius != results[j].Position.Radius {
+			return results[i].Position.Radius < results[j].Position.Radius
+		}
+		return results[i].Position.Angle < results[j].Position.Angle
+	})
+}
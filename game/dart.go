@@ -0,0 +1,55 @@
+package game
+
+import (
+	boardgeo "DStratMC/board-geometry"
+)
+
+// Dart is where one dart landed, and what it scored
+type Dart struct {
+	Position   boardgeo.BoardPosition
+	Area       boardgeo.BoardArea
+	Segment    int // Point value of the segment, before the multiplier: 1 to 20, 25 for the bulls, 0 if out
+	Multiplier int // 1, 2, or 3, with the inner bull a double; 0 if out
+	Score      int
+}
+
+// NewDart scores a dart that landed at the given position on the board
+func NewDart(position boardgeo.BoardPosition) Dart {
+	area, score, _ := boardgeo.DescribeBoardPoint(position)
+	multiplier := multiplierOfArea(area)
+	segment := 0
+	if multiplier > 0 {
+		segment = score / multiplier
+	}
+	return Dart{
+		Position:   position,
+		Area:       area,
+		Segment:    segment,
+		Multiplier: multiplier,
+		Score:      score,
+	}
+}
+
+// IsDouble returns true if the dart scored a double, including the inner bull
+func (d Dart) IsDouble() bool {
+	return d.Multiplier == 2
+}
+
+// IsTreble returns true if the dart scored a treble
+func (d Dart) IsTreble() bool {
+	return d.Multiplier == 3
+}
+
+// multiplierOfArea returns the multiplier for a score in the given area of the board
+func multiplierOfArea(area boardgeo.BoardArea) int {
+	switch area {
+	case boardgeo.BoardArea_InnerBull, boardgeo.BoardArea_Double:
+		return 2
+	case boardgeo.BoardArea_Treble:
+		return 3
+	case boardgeo.BoardArea_OuterBull, boardgeo.BoardArea_InnerSingle, boardgeo.BoardArea_OuterSingle:
+		return 1
+	default:
+		return 0
+	}
+}
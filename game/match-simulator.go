@@ -0,0 +1,181 @@
+package game
+
+//	The match simulator extends the Monte-Carlo approach from single targets to whole games: two players, each
+//	with their own accuracy model and way of choosing where to aim, play thousands of simulated x01 legs against
+//	each other, taking turns to throw first, and the statistics a player would quote are collected for each.
+
+import (
+	"DStratMC/simulation"
+	"context"
+	"errors"
+)
+
+var ErrNeedTwoPlayers = errors.New("a match simulation needs two players")
+var ErrLegNeverFinished = errors.New("a simulated leg went on too long; neither player seems able to finish")
+
+// A leg with this many darts thrown, between both players, is given up as one that will never finish, for
+// example because neither player's darts can land on the board.  A real leg takes a small fraction of this.
+const maxDartsPerLeg = 3000
+
+// Seeds of the two players' models are made different by mixing in this odd constant, the golden ratio in 64 bits
+const playerSeedMix = 0x9e3779b97f4a7c15
+
+// MatchPlayer is one side of a simulated match
+type MatchPlayer struct {
+	Name     string
+	Model    simulation.AccuracyModel
+	Strategy AimStrategy
+}
+
+// MatchPlayerStats are one player's results over the simulated legs
+type MatchPlayerStats struct {
+	Name               string
+	LegsWon            int
+	LegWinProbability  float64
+	DartsThrown        int
+	PointsScored       int     // Points taken off the player's score; a bust visit scores nothing
+	DartsPerLegWon     float64 // Average darts thrown in the legs the player won
+	ThreeDartAverage   float64 // Average points per visit of three darts
+	CheckoutAttempts   int     // Darts thrown with a score that one dart could finish
+	Checkouts          int
+	CheckoutPercentage float64 // Percentage of checkout attempts that finished the leg
+}
+
+// MatchStats are the results of a match simulation
+type MatchStats struct {
+	Legs    int
+	Players [2]MatchPlayerStats
+}
+
+// MatchProgressCallback is called after each leg with the fraction of the legs played
+type MatchProgressCallback func(fractionComplete float64)
+
+// MatchSimulator plays simulated legs between two players
+type MatchSimulator interface {
+	SetSeed(seed uint64)
+	Run(ctx context.Context, numLegs int, progress MatchProgressCallback) (MatchStats, error)
+}
+
+// MatchSimulatorInstance is the data for a match simulation
+type MatchSimulatorInstance struct {
+	players [2]MatchPlayer
+	rules   X01Rules
+}
+
+// NewMatchSimulator creates a simulation of legs between two players under the given rules; only the starting
+// score, and the rules for starting and finishing, matter, as every leg is played on its own.  Each player's
+// model is copied, so the models given aren't changed.
+func NewMatchSimulator(players []MatchPlayer, rules X01Rules) (MatchSimulator, error) {
+	if len(players) != 2 {
+		return nil, ErrNeedTwoPlayers
+	}
+	rules.LegsPerSet = 1
+	rules.SetsPerMatch = 1
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	instance := &MatchSimulatorInstance{rules: rules}
+	for i, player := range players {
+		instance.players[i] = MatchPlayer{Name: player.Name, Model: player.Model.Clone(), Strategy: player.Strategy}
+	}
+	return instance, nil
+}
+
+// SetSeed makes the simulation repeatable: the same seed gives the same legs every time
+func (m *MatchSimulatorInstance) SetSeed(seed uint64) {
+	for i := range m.players {
+		m.players[i].Model.SetSeed(seed ^ (uint64(i+1) * playerSeedMix))
+	}
+}
+
+// Run plays the given number of legs, with the players taking turns to throw first, and returns their statistics.
+// The progress callback, if not nil, is called after each leg.  If the context is cancelled, or a throw fails,
+// the simulation stops and the statistics of the legs completed are returned with the error.
+func (m *MatchSimulatorInstance) Run(ctx context.Context, numLegs int, progress MatchProgressCallback) (MatchStats, error) {
+	stats := MatchStats{}
+	for i := range m.players {
+		stats.Players[i].Name = m.players[i].Name
+	}
+	var err error
+	for leg := 0; leg < numLegs && err == nil; leg++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		err = m.playLeg(leg%2, &stats)
+		if progress != nil {
+			progress(float64(leg+1) / float64(numLegs))
+		}
+	}
+	for i := range stats.Players {
+		player := &stats.Players[i]
+		if stats.Legs > 0 {
+			player.LegWinProbability = float64(player.LegsWon) / float64(stats.Legs)
+		}
+		if player.DartsThrown > 0 {
+			player.ThreeDartAverage = float64(DartsPerVisit*player.PointsScored) / float64(player.DartsThrown)
+		}
+		if player.CheckoutAttempts > 0 {
+			player.CheckoutPercentage = 100 * float64(player.Checkouts) / float64(player.CheckoutAttempts)
+		}
+	}
+	return stats, err
+}
+
+// playLeg plays one leg, with the given player throwing first, and adds it to the statistics.  If the leg isn't
+// finished within the limit on darts, ErrLegNeverFinished is returned.
+func (m *MatchSimulatorInstance) playLeg(firstPlayer int, stats *MatchStats) error {
+	leg, err := NewX01Game(m.rules, len(m.players))
+	if err != nil {
+		return err
+	}
+	//	Player 0 of the game throws first, so is the first player of the match
+	matchPlayer := func(gamePlayer int) int {
+		return (gamePlayer + firstPlayer) % len(m.players)
+	}
+	var dartsInLeg [2]int
+	var visitStart [2]int
+	for i := range visitStart {
+		visitStart[i] = m.rules.StartingScore
+	}
+	for dartsThrown := 0; !leg.IsMatchOver(); dartsThrown++ {
+		if dartsThrown == maxDartsPerLeg {
+			return ErrLegNeverFinished
+		}
+		gamePlayer := leg.GetCurrentPlayer()
+		index := matchPlayer(gamePlayer)
+		player := m.players[index]
+		remaining := leg.GetRemaining(gamePlayer)
+		dartsLeft := leg.GetDartsLeftInVisit()
+		if dartsLeft == DartsPerVisit {
+			visitStart[index] = remaining
+		}
+		aim := player.Strategy.ChooseAim(remaining, dartsLeft, visitStart[index], leg.IsOpened(gamePlayer))
+		position, err := player.Model.GetThrow(aim)
+		if err != nil {
+			return err
+		}
+		opened := leg.IsOpened(gamePlayer)
+		result, err := leg.Throw(position)
+		if err != nil {
+			return err
+		}
+
+		playerStats := &stats.Players[index]
+		playerStats.DartsThrown++
+		dartsInLeg[index]++
+		if opened && m.rules.IsOneDartFinish(remaining) {
+			playerStats.CheckoutAttempts++
+		}
+		if result.VisitOver {
+			playerStats.PointsScored += visitStart[index] - result.Remaining
+		}
+		if result.LegWon {
+			playerStats.Checkouts++
+			playerStats.LegsWon++
+			total := playerStats.DartsPerLegWon*float64(playerStats.LegsWon-1) + float64(dartsInLeg[index])
+			playerStats.DartsPerLegWon = total / float64(playerStats.LegsWon)
+		}
+	}
+	stats.Legs++
+	return nil
+}
@@ -0,0 +1,36 @@
+package game
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	"context"
+	"errors"
+	"testing"
+)
+
+// offBoardAimStrategy always aims off the board, so a perfectly accurate player never scores
+type offBoardAimStrategy struct{}
+
+func (s offBoardAimStrategy) ChooseAim(_ int, _ int, _ int, _ bool) boardgeo.BoardPosition {
+	return boardgeo.CreateBoardPositionFromPolar(2.5, 0)
+}
+
+// TestMatchSimulatorGivesUpOnUnfinishableLeg checks that a leg neither player can finish ends with an error
+// rather than running for ever
+func TestMatchSimulatorGivesUpOnUnfinishableLeg(t *testing.T) {
+	players := []MatchPlayer{
+		{Name: "A", Model: simulation.NewPerfectAccuracyModel(), Strategy: offBoardAimStrategy{}},
+		{Name: "B", Model: simulation.NewPerfectAccuracyModel(), Strategy: offBoardAimStrategy{}},
+	}
+	simulator, err := NewMatchSimulator(players, NewX01Rules(501))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := simulator.Run(context.Background(), 5, nil)
+	if !errors.Is(err, ErrLegNeverFinished) {
+		t.Fatalf("got error %v, want ErrLegNeverFinished", err)
+	}
+	if stats.Legs != 0 {
+		t.Errorf("got %d legs completed, want 0", stats.Legs)
+	}
+}
@@ -0,0 +1,215 @@
+package game
+
+//	An x01 match between one or more players, played dart by dart from where each dart lands on the board, so
+//	that simulated throws from an accuracy model, or real throws clicked on the board, can be played as a game.
+//	Players take turns at three-dart visits.  The first to check out wins the leg, the first to win enough legs
+//	wins the set, and the first to win enough sets wins the match.  The player who throws first alternates from
+//	leg to leg, and the first leg of each set is started by the player after the one who started the last set.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"errors"
+)
+
+var ErrMatchOver = errors.New("the match is over")
+var ErrNoPlayers = errors.New("a match needs at least one player")
+
+// X01Game is a match of 501, 301, or another x01 game, under a given set of rules
+type X01Game interface {
+	Throw(position boardgeo.BoardPosition) (ThrowResult, error)
+	GetRules() X01Rules
+	GetNumPlayers() int
+	GetCurrentPlayer() int
+	GetRemaining(player int) int
+	GetDartsLeftInVisit() int
+	IsOpened(player int) bool
+	GetLegsWon(player int) int
+	GetSetsWon(player int) int
+	IsMatchOver() bool
+	GetWinner() int
+}
+
+// ThrowResult is what happened when a dart was thrown
+type ThrowResult struct {
+	Player          int
+	Dart            Dart
+	Outcome         DartOutcome
+	RemainingBefore int  // The player's score before the dart
+	Remaining       int  // The player's score after the dart, or after the visit if it was bust
+	VisitOver       bool // This was the last dart of the player's visit
+	LegWon          bool
+	SetWon          bool
+	MatchWon        bool
+}
+
+// X01GameInstance is the state of a match in progress
+type X01GameInstance struct {
+	rules           X01Rules
+	remaining       []int
+	opened          []bool
+	legsWon         []int
+	setsWon         []int
+	currentPlayer   int
+	visitStartScore int
+	dartsLeft       int
+	legStarter      int
+	setStarter      int
+	matchOver       bool
+	winner          int
+}
+
+// NewX01Game creates a match between numPlayers players under the given rules, with player 0 to throw first
+func NewX01Game(rules X01Rules, numPlayers int) (X01Game, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if numPlayers < 1 {
+		return nil, ErrNoPlayers
+	}
+	instance := &X01GameInstance{
+		rules:     rules,
+		remaining: make([]int, numPlayers),
+		opened:    make([]bool, numPlayers),
+		legsWon:   make([]int, numPlayers),
+		setsWon:   make([]int, numPlayers),
+		winner:    -1,
+	}
+	instance.startLeg(0)
+	return instance, nil
+}
+
+// Throw plays a dart, for the player whose turn it is, that landed at the given position, and returns what
+// happened.  Once the match is over, ErrMatchOver is returned.
+func (g *X01GameInstance) Throw(position boardgeo.BoardPosition) (ThrowResult, error) {
+	if g.matchOver {
+		return ThrowResult{}, ErrMatchOver
+	}
+	player := g.currentPlayer
+	dart := NewDart(position)
+	outcome, remaining, opened := g.rules.ApplyDart(g.remaining[player], g.opened[player], dart)
+	result := ThrowResult{
+		Player:          player,
+		Dart:            dart,
+		Outcome:         outcome,
+		RemainingBefore: g.remaining[player],
+		Remaining:       remaining,
+	}
+	g.remaining[player] = remaining
+	g.opened[player] = opened
+	g.dartsLeft--
+
+	switch outcome {
+	case DartOutcome_Bust:
+		g.remaining[player] = g.visitStartScore
+		result.Remaining = g.visitStartScore
+		result.VisitOver = true
+		g.nextVisit()
+	case DartOutcome_Checkout:
+		result.VisitOver = true
+		result.LegWon = true
+		g.legWon(player, &result)
+	default:
+		if g.dartsLeft == 0 {
+			result.VisitOver = true
+			g.nextVisit()
+		}
+	}
+	return result, nil
+}
+
+// legWon records the leg won by the given player, and the set and match if they are won too, then starts the
+// next leg unless the match is over
+func (g *X01GameInstance) legWon(player int, result *ThrowResult) {
+	g.legsWon[player]++
+	if g.legsWon[player] < g.rules.LegsPerSet {
+		g.startLeg((g.legStarter + 1) % len(g.remaining))
+		return
+	}
+	result.SetWon = true
+	g.setsWon[player]++
+	if g.setsWon[player] >= g.rules.SetsPerMatch {
+		result.MatchWon = true
+		g.matchOver = true
+		g.winner = player
+		return
+	}
+	for i := range g.legsWon {
+		g.legsWon[i] = 0
+	}
+	g.setStarter = (g.setStarter + 1) % len(g.remaining)
+	g.startLeg(g.setStarter)
+}
+
+// startLeg resets every player's score for a new leg, with the given player to throw first
+func (g *X01GameInstance) startLeg(starter int) {
+	for i := range g.remaining {
+		g.remaining[i] = g.rules.StartingScore
+		g.opened[i] = !g.rules.DoubleIn
+	}
+	g.legStarter = starter
+	g.currentPlayer = starter
+	g.startVisit()
+}
+
+// nextVisit passes the throw to the next player
+func (g *X01GameInstance) nextVisit() {
+	g.currentPlayer = (g.currentPlayer + 1) % len(g.remaining)
+	g.startVisit()
+}
+
+// startVisit gives the current player a fresh three darts, noting their score in case the visit is bust
+func (g *X01GameInstance) startVisit() {
+	g.dartsLeft = DartsPerVisit
+	g.visitStartScore = g.remaining[g.currentPlayer]
+}
+
+// GetRules returns the rules of the match
+func (g *X01GameInstance) GetRules() X01Rules {
+	return g.rules
+}
+
+// GetNumPlayers returns the number of players in the match
+func (g *X01GameInstance) GetNumPlayers() int {
+	return len(g.remaining)
+}
+
+// GetCurrentPlayer returns the player whose turn it is to throw
+func (g *X01GameInstance) GetCurrentPlayer() int {
+	return g.currentPlayer
+}
+
+// GetRemaining returns the given player's score still to get in the current leg
+func (g *X01GameInstance) GetRemaining(player int) int {
+	return g.remaining[player]
+}
+
+// GetDartsLeftInVisit returns the number of darts the current player has left to throw in this visit
+func (g *X01GameInstance) GetDartsLeftInVisit() int {
+	return g.dartsLeft
+}
+
+// IsOpened returns true if the given player's darts count in the current leg; only false with double in,
+// before the player has hit a double
+func (g *X01GameInstance) IsOpened(player int) bool {
+	return g.opened[player]
+}
+
+// GetLegsWon returns the number of legs the given player has won in the current set
+func (g *X01GameInstance) GetLegsWon(player int) int {
+	return g.legsWon[player]
+}
+
+// GetSetsWon returns the number of sets the given player has won
+func (g *X01GameInstance) GetSetsWon(player int) int {
+	return g.setsWon[player]
+}
+
+// IsMatchOver returns true once a player has won the match
+func (g *X01GameInstance) IsMatchOver() bool {
+	return g.matchOver
+}
+
+// GetWinner returns the player who won the match, or -1 if it isn't over
+func (g *X01GameInstance) GetWinner() int {
+	return g.winner
+}
@@ -0,0 +1,145 @@
+package game
+
+//	The rules of the x01 games (501, 301, and so on).  Each player starts with the same score and counts down
+//	to exactly zero, three darts to a visit.  Depending on the rules, a player's darts may not count until they
+//	have hit a double ("double in"), and the last dart must be a double ("double out"), or a double or treble
+//	("master out").  A visit that would take the score below zero, or to zero without a proper finishing dart,
+//	or to a score that can't then be finished, is a bust: the score goes back to what it was at the start of
+//	the visit, and the visit ends.
+
+import (
+	"errors"
+)
+
+// FinishRule is how the last dart of a leg must score
+type FinishRule int
+
+const (
+	FinishRule_Straight FinishRule = iota // Any dart that reaches exactly zero
+	FinishRule_Double                     // A double, or the inner bull
+	FinishRule_Master                     // A double, a treble, or the inner bull
+)
+
+// FinishRuleDescription names each finishing rule, as it would appear on the command line or in a menu
+var FinishRuleDescription = map[FinishRule]string{
+	FinishRule_Straight: "straight",
+	FinishRule_Double:   "double",
+	FinishRule_Master:   "master",
+}
+
+// ParseFinishRule returns the finishing rule with the given name, as in FinishRuleDescription
+func ParseFinishRule(name string) (FinishRule, bool) {
+	for rule, description := range FinishRuleDescription {
+		if description == name {
+			return rule, true
+		}
+	}
+	return FinishRule_Straight, false
+}
+
+// Number of darts in a visit to the board
+const DartsPerVisit = 3
+
+// X01Rules are the rules of one x01 match
+type X01Rules struct {
+	StartingScore int
+	DoubleIn      bool // A player's darts don't count until they hit a double
+	Finish        FinishRule
+	LegsPerSet    int // Legs needed to win a set
+	SetsPerMatch  int // Sets needed to win the match
+}
+
+var ErrInvalidStartingScore = errors.New("the starting score must be at least 2")
+var ErrInvalidMatchLength = errors.New("at least 1 leg per set and 1 set per match are needed")
+
+// NewX01Rules returns the usual rules for a single leg of the game with the given starting score (e.g. 501):
+// straight in, double out
+func NewX01Rules(startingScore int) X01Rules {
+	return X01Rules{
+		StartingScore: startingScore,
+		DoubleIn:      false,
+		Finish:        FinishRule_Double,
+		LegsPerSet:    1,
+		SetsPerMatch:  1,
+	}
+}
+
+// Validate returns an error if the rules describe a game that can't be played
+func (r X01Rules) Validate() error {
+	if r.StartingScore < 2 {
+		return ErrInvalidStartingScore
+	}
+	if r.LegsPerSet < 1 || r.SetsPerMatch < 1 {
+		return ErrInvalidMatchLength
+	}
+	return nil
+}
+
+// DartOutcome is the effect of one dart on a player's score
+type DartOutcome int
+
+const (
+	DartOutcome_Scored    DartOutcome = iota // The dart's score was taken off
+	DartOutcome_NotOpened                    // Double in, and the player hasn't yet hit a double, so it didn't count
+	DartOutcome_Bust                         // The visit is bust
+	DartOutcome_Checkout                     // The dart finished the leg
+)
+
+// ApplyDart works out the effect of a dart on a player's remaining score under these rules.  opened is whether
+// the player's darts count yet (always true without double in).  It returns the outcome, the remaining score
+// after the dart, and whether the player's darts now count.  After a bust, the remaining score returned is the
+// one passed in; restoring the score from the start of the visit is up to the caller.
+func (r X01Rules) ApplyDart(remaining int, opened bool, dart Dart) (DartOutcome, int, bool) {
+	if !opened {
+		if !r.DoubleIn || dart.IsDouble() {
+			opened = true
+		} else {
+			return DartOutcome_NotOpened, remaining, false
+		}
+	}
+	after := remaining - dart.Score
+	switch {
+	case after < 0:
+		return DartOutcome_Bust, remaining, opened
+	case after == 0 && r.IsFinishingDart(dart):
+		return DartOutcome_Checkout, 0, opened
+	case after == 0:
+		return DartOutcome_Bust, remaining, opened
+	case after < r.lowestFinish():
+		return DartOutcome_Bust, remaining, opened
+	}
+	return DartOutcome_Scored, after, opened
+}
+
+// IsFinishingDart returns true if a dart may be the last of a leg under these rules
+func (r X01Rules) IsFinishingDart(dart Dart) bool {
+	switch r.Finish {
+	case FinishRule_Double:
+		return dart.IsDouble()
+	case FinishRule_Master:
+		return dart.IsDouble() || dart.IsTreble()
+	default:
+		return dart.Score > 0
+	}
+}
+
+// IsOneDartFinish returns true if a single dart could finish the leg from the given remaining score
+func (r X01Rules) IsOneDartFinish(remaining int) bool {
+	for multiplier := 1; multiplier <= 3; multiplier++ {
+		for segment := 1; segment <= 20; segment++ {
+			if segment*multiplier == remaining && r.IsFinishingDart(Dart{Segment: segment, Multiplier: multiplier, Score: remaining}) {
+				return true
+			}
+		}
+	}
+	return (remaining == 25 && r.IsFinishingDart(Dart{Segment: 25, Multiplier: 1, Score: 25})) ||
+		(remaining == 50 && r.IsFinishingDart(Dart{Segment: 25, Multiplier: 2, Score: 50}))
+}
+
+// lowestFinish returns the smallest score that can be finished in one dart; a visit leaving less is bust
+func (r X01Rules) lowestFinish() int {
+	if r.Finish == FinishRule_Straight {
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"testing"
+)
+
+// dartIn returns a dart landing in the middle of the given area of a segment, e.g. the treble 20
+func dartIn(area boardgeo.BoardArea, segment int) Dart {
+	return NewDart(regionCentre(area, segment))
+}
+
+// TestApplyDart checks scoring, checkouts, and busts under each finishing rule, and opening under double in
+func TestApplyDart(t *testing.T) {
+	straight, double, master := NewX01Rules(501), NewX01Rules(501), NewX01Rules(501)
+	straight.Finish = FinishRule_Straight
+	master.Finish = FinishRule_Master
+	doubleIn := NewX01Rules(501)
+	doubleIn.DoubleIn = true
+	innerBull := NewDart(boardgeo.CreateBoardPositionFromPolar(0, 0))
+
+	tests := []struct {
+		name          string
+		rules         X01Rules
+		remaining     int
+		opened        bool
+		dart          Dart
+		wantOutcome   DartOutcome
+		wantRemaining int
+		wantOpened    bool
+	}{
+		{"score", double, 501, true, dartIn(boardgeo.BoardArea_Treble, 20), DartOutcome_Scored, 441, true},
+		{"double out", double, 32, true, dartIn(boardgeo.BoardArea_Double, 16), DartOutcome_Checkout, 0, true},
+		{"bull out", double, 50, true, innerBull, DartOutcome_Checkout, 0, true},
+		{"leaves 1", double, 21, true, dartIn(boardgeo.BoardArea_OuterSingle, 20), DartOutcome_Bust, 21, true},
+		{"overshoot", double, 16, true, dartIn(boardgeo.BoardArea_Treble, 20), DartOutcome_Bust, 16, true},
+		{"single to zero", double, 20, true, dartIn(boardgeo.BoardArea_OuterSingle, 20), DartOutcome_Bust, 20, true},
+		{"treble to zero", double, 60, true, dartIn(boardgeo.BoardArea_Treble, 20), DartOutcome_Bust, 60, true},
+		{"master out on a treble", master, 60, true, dartIn(boardgeo.BoardArea_Treble, 20), DartOutcome_Checkout, 0, true},
+		{"master out on a single", master, 20, true, dartIn(boardgeo.BoardArea_OuterSingle, 20), DartOutcome_Bust, 20, true},
+		{"master leaves 1", master, 61, true, dartIn(boardgeo.BoardArea_Treble, 20), DartOutcome_Bust, 61, true},
+		{"straight out on a single", straight, 20, true, dartIn(boardgeo.BoardArea_InnerSingle, 20), DartOutcome_Checkout, 0, true},
+		{"straight leaves 1", straight, 21, true, dartIn(boardgeo.BoardArea_OuterSingle, 20), DartOutcome_Scored, 1, true},
+		{"straight overshoot", straight, 1, true, dartIn(boardgeo.BoardArea_OuterSingle, 5), DartOutcome_Bust, 1, true},
+		{"miss", double, 40, true, NewDart(boardgeo.CreateBoardPositionFromPolar(1.5, 0)), DartOutcome_Scored, 40, true},
+		{"not opened", doubleIn, 501, false, dartIn(boardgeo.BoardArea_Treble, 20), DartOutcome_NotOpened, 501, false},
+		{"opened with a double", doubleIn, 501, false, dartIn(boardgeo.BoardArea_Double, 20), DartOutcome_Scored, 461, true},
+	}
+	for _, test := range tests {
+		outcome, remaining, opened := test.rules.ApplyDart(test.remaining, test.opened, test.dart)
+		if outcome != test.wantOutcome || remaining != test.wantRemaining || opened != test.wantOpened {
+			t.Errorf("%s: got %v, %d, %v; want %v, %d, %v", test.name, outcome, remaining, opened,
+				test.wantOutcome, test.wantRemaining, test.wantOpened)
+		}
+	}
+}
+
+// TestX01GameBustRestoresVisitScore checks that a bust puts the score back to where it was at the start of the
+// visit, and passes the throw to the next player
+func TestX01GameBustRestoresVisitScore(t *testing.T) {
+	game, err := NewX01Game(NewX01Rules(101), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := game.Throw(regionCentre(boardgeo.BoardArea_Treble, 20)); err != nil {
+		t.Fatal(err)
+	}
+	result, err := game.Throw(regionCentre(boardgeo.BoardArea_Treble, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Outcome != DartOutcome_Bust || !result.VisitOver || result.Remaining != 101 {
+		t.Errorf("got outcome %v, visit over %v, remaining %d; want a bust back to 101", result.Outcome, result.VisitOver, result.Remaining)
+	}
+	if game.GetRemaining(0) != 101 || game.GetCurrentPlayer() != 1 || game.GetDartsLeftInVisit() != DartsPerVisit {
+		t.Errorf("after the bust: player 0 on %d, player %d to throw with %d darts; want 101, player 1, %d darts",
+			game.GetRemaining(0), game.GetCurrentPlayer(), game.GetDartsLeftInVisit(), DartsPerVisit)
+	}
+}
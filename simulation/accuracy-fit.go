@@ -0,0 +1,136 @@
+package simulation
+
+//	Fitting an accuracy model to real throws.  Every throw is reduced to its miss vector (where the dart
+//	landed, minus where it was aimed), and we estimate the parameters of a two-dimensional normal
+//	distribution of those vectors: the mean (the player's systematic bias), the standard deviation on
+//	each axis, and the correlation between the axes.  Each estimate comes with a 95% confidence interval,
+//	so the player can see how much the small number of throws they have recorded really tells us.
+
+import (
+	"errors"
+	"gonum.org/v1/gonum/stat/distuv"
+	"math"
+)
+
+// Confidence level of the intervals reported for each fitted parameter
+const fitConfidenceLevel = 0.95
+
+// ParameterEstimate is a fitted parameter value, with the lower and upper bounds of its confidence interval
+type ParameterEstimate struct {
+	Value float64
+	Lower float64
+	Upper float64
+}
+
+// AccuracyFit is the result of fitting a bivariate normal distribution to a set of miss vectors.
+// All values are in normalized board units.
+type AccuracyFit struct {
+	NumThrows   int
+	MeanOffsetX ParameterEstimate
+	MeanOffsetY ParameterEstimate
+	StdDevX     ParameterEstimate
+	StdDevY     ParameterEstimate
+	Correlation ParameterEstimate
+}
+
+// ErrTooFewThrows is returned when there are not enough throws to estimate the spread of a distribution
+var ErrTooFewThrows = errors.New("at least 2 throws are needed to fit an accuracy model")
+
+// FitAccuracy fits a bivariate normal distribution to the given miss vectors
+//
+//	The means and standard deviations are the usual sample estimates (with n-1 in the denominator), and the
+//	correlation is the sample correlation coefficient.  Confidence intervals are:
+//	  - mean: Student's t interval, since the standard deviation is itself estimated;
+//	  - standard deviation: the chi-squared interval for a normal sample's variance;
+//	  - correlation: Fisher's z-transformation interval (needs at least 4 throws, otherwise the whole -1 to 1 range)
+func FitAccuracy(missVectors []MissVector) (AccuracyFit, error) {
+	n := len(missVectors)
+	if n < 2 {
+		return AccuracyFit{}, ErrTooFewThrows
+	}
+	count := float64(n)
+
+	var sumX, sumY float64
+	for _, miss := range missVectors {
+		sumX += miss.X
+		sumY += miss.Y
+	}
+	meanX := sumX / count
+	meanY := sumY / count
+
+	var sumSquaresX, sumSquaresY, sumProducts float64
+	for _, miss := range missVectors {
+		sumSquaresX += (miss.X - meanX) * (miss.X - meanX)
+		sumSquaresY += (miss.Y - meanY) * (miss.Y - meanY)
+		sumProducts += (miss.X - meanX) * (miss.Y - meanY)
+	}
+	stdDevX := math.Sqrt(sumSquaresX / (count - 1))
+	stdDevY := math.Sqrt(sumSquaresY / (count - 1))
+	correlation := 0.0
+	if sumSquaresX > 0 && sumSquaresY > 0 {
+		correlation = sumProducts / math.Sqrt(sumSquaresX*sumSquaresY)
+	}
+
+	tailProbability := (1 - fitConfidenceLevel) / 2
+	return AccuracyFit{
+		NumThrows:   n,
+		MeanOffsetX: meanEstimate(meanX, stdDevX, n, tailProbability),
+		MeanOffsetY: meanEstimate(meanY, stdDevY, n, tailProbability),
+		StdDevX:     stdDevEstimate(stdDevX, n, tailProbability),
+		StdDevY:     stdDevEstimate(stdDevY, n, tailProbability),
+		Correlation: correlationEstimate(correlation, n, tailProbability),
+	}, nil
+}
+
+// meanEstimate returns a sample mean with its Student's t confidence interval
+func meanEstimate(mean float64, stdDev float64, n int, tailProbability float64) ParameterEstimate {
+	studentsT := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(n - 1)}
+	halfWidth := studentsT.Quantile(1-tailProbability) * stdDev / math.Sqrt(float64(n))
+	return ParameterEstimate{Value: mean, Lower: mean - halfWidth, Upper: mean + halfWidth}
+}
+
+// stdDevEstimate returns a sample standard deviation with its chi-squared confidence interval
+func stdDevEstimate(stdDev float64, n int, tailProbability float64) ParameterEstimate {
+	degreesOfFreedom := float64(n - 1)
+	chiSquared := distuv.ChiSquared{K: degreesOfFreedom}
+	sumOfSquares := degreesOfFreedom * stdDev * stdDev
+	return ParameterEstimate{
+		Value: stdDev,
+		Lower: math.Sqrt(sumOfSquares / chiSquared.Quantile(1-tailProbability)),
+		Upper: math.Sqrt(sumOfSquares / chiSquared.Quantile(tailProbability)),
+	}
+}
+
+// correlationEstimate returns a sample correlation with its Fisher z-transformation confidence interval
+func correlationEstimate(correlation float64, n int, tailProbability float64) ParameterEstimate {
+	if n < 4 {
+		return ParameterEstimate{Value: correlation, Lower: -1, Upper: 1}
+	}
+	unitNormal := distuv.Normal{Mu: 0, Sigma: 1}
+	// Keep away from +/-1, where the transformation is infinite
+	z := math.Atanh(math.Max(-maxFittedCorrelation, math.Min(maxFittedCorrelation, correlation)))
+	halfWidth := unitNormal.Quantile(1-tailProbability) / math.Sqrt(float64(n-3))
+	return ParameterEstimate{
+		Value: correlation,
+		Lower: math.Tanh(z - halfWidth),
+		Upper: math.Tanh(z + halfWidth),
+	}
+}
+
+// Largest correlation magnitude used when building a model (exactly 1 would be a distribution along a line)
+const maxFittedCorrelation = 0.999
+
+// PooledStdDev returns the single standard deviation that best describes the scatter if it is assumed to be
+// the same in every direction, as in the NormalAccuracyModel
+func (f AccuracyFit) PooledStdDev() float64 {
+	return math.Sqrt((f.StdDevX.Value*f.StdDevX.Value + f.StdDevY.Value*f.StdDevY.Value) / 2)
+}
+
+// Model returns a ready-to-use accuracy model with the fitted parameters: a bivariate normal distribution
+// with the fitted spread and correlation, offset by the fitted bias
+func (f AccuracyFit) Model() AccuracyModel {
+	correlation := math.Max(-maxFittedCorrelation, math.Min(maxFittedCorrelation, f.Correlation.Value))
+	model := NewBivariateNormalAccuracyModel(f.StdDevX.Value, f.StdDevY.Value, correlation)
+	model.SetMeanOffset(f.MeanOffsetX.Value, f.MeanOffsetY.Value)
+	return model
+}
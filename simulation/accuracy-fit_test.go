@@ -0,0 +1,86 @@
+package simulation
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestFitAccuracyKnownThrows checks the fit of four throws against values, and confidence intervals, worked out
+// by hand from tables of Student's t and chi-squared
+func TestFitAccuracyKnownThrows(t *testing.T) {
+	missVectors := []MissVector{{0.01, 0.02}, {0.02, 0.01}, {0.03, 0.04}, {0.04, 0.03}}
+	fit, err := FitAccuracy(missVectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdDev := math.Sqrt(5.0/3.0) * 0.01
+	tests := []struct {
+		name string
+		got  ParameterEstimate
+		want ParameterEstimate
+	}{
+		{"mean x", fit.MeanOffsetX, ParameterEstimate{0.025, 0.025 - 0.0205426026, 0.025 + 0.0205426026}},
+		{"mean y", fit.MeanOffsetY, ParameterEstimate{0.025, 0.025 - 0.0205426026, 0.025 + 0.0205426026}},
+		{"std dev x", fit.StdDevX, ParameterEstimate{stdDev, 0.0073133486, 0.0481353383}},
+		{"std dev y", fit.StdDevY, ParameterEstimate{stdDev, 0.0073133486, 0.0481353383}},
+		{"correlation", fit.Correlation, ParameterEstimate{0.6, -0.8529325648, 0.9901277108}},
+	}
+	for _, test := range tests {
+		if math.Abs(test.got.Value-test.want.Value) > 1e-9 ||
+			math.Abs(test.got.Lower-test.want.Lower) > 1e-6 ||
+			math.Abs(test.got.Upper-test.want.Upper) > 1e-6 {
+			t.Errorf("%s: got %+v, want %+v", test.name, test.got, test.want)
+		}
+	}
+	if fit.NumThrows != 4 {
+		t.Errorf("got %d throws, want 4", fit.NumThrows)
+	}
+}
+
+// TestFitAccuracyRecoversModel checks that fitting many throws from a known bivariate normal model finds its
+// parameters, each inside its confidence interval
+func TestFitAccuracyRecoversModel(t *testing.T) {
+	const offsetX, offsetY, stdDevX, stdDevY, correlation = 0.01, -0.02, 0.05, 0.08, 0.4
+	model := NewBivariateNormalAccuracyModel(stdDevX, stdDevY, correlation)
+	model.SetMeanOffset(offsetX, offsetY)
+	model.SetSeed(11)
+	centre := boardgeo.CreateBoardPositionFromPolar(0, 0)
+	missVectors := make([]MissVector, 0, 5000)
+	for i := 0; i < 5000; i++ {
+		hit, err := model.GetThrow(centre)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, y := boardgeo.GetCartesian(hit)
+		missVectors = append(missVectors, MissVector{X: x, Y: y})
+	}
+	fit, err := FitAccuracy(missVectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		got   ParameterEstimate
+		truth float64
+	}{
+		{"mean x", fit.MeanOffsetX, offsetX},
+		{"mean y", fit.MeanOffsetY, offsetY},
+		{"std dev x", fit.StdDevX, stdDevX},
+		{"std dev y", fit.StdDevY, stdDevY},
+		{"correlation", fit.Correlation, correlation},
+	}
+	for _, test := range tests {
+		if test.truth < test.got.Lower || test.truth > test.got.Upper {
+			t.Errorf("%s: true value %g outside the interval %+v", test.name, test.truth, test.got)
+		}
+	}
+}
+
+// TestFitAccuracyTooFewThrows checks that one throw can't be fitted
+func TestFitAccuracyTooFewThrows(t *testing.T) {
+	if _, err := FitAccuracy([]MissVector{{0.01, 0.02}}); !errors.Is(err, ErrTooFewThrows) {
+		t.Errorf("got error %v, want ErrTooFewThrows", err)
+	}
+}
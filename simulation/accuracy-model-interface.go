@@ -2,19 +2,91 @@ package simulation
 
 import (
 	boardgeo "DStratMC/board-geometry"
-	"image"
 )
 
 // AccuracyModel is an abstract model that is used to determine a simulated thrower's accuracy -
 // how close they will come to their intended target when they throw.
 // Accuracy is determined by the type of accuracy model used - a variety of
 // implementations will provide models of different levels of complexity.
+//
+// Throws are generated entirely in normalized board space (1.0 being the outer edge of the
+// scoring area), so results do not depend on the size of the window the board is drawn in,
+// and a model can be used with no dartboard displayed at all.
+//
+// Each model owns its random number source, which is not safe to share between threads, so a model used on
+// several threads at once should be cloned for each.  SetSeed makes the throws that follow a fixed sequence,
+// the same every time for the same seed, so that a simulation can be repeated exactly.  Clone returns a copy
+// of the model with the same parameters and its own random number source, started from an unpredictable seed.
 type AccuracyModel interface {
-	GetThrow(target boardgeo.BoardPosition,
-		scoringRadius float64,
-		squareDimension float64,
-		startPoint image.Point) (boardgeo.BoardPosition, error)
+	GetThrow(target boardgeo.BoardPosition) (boardgeo.BoardPosition, error)
 	GetAccuracyRadius() float64
-	GetSigmaRadius(numSigmas float64) float64
+	GetSigmaEllipse(numSigmas float64) SigmaEllipse
 	SetStandardDeviation(stdDev float64)
+	SetMeanOffset(offsetX float64, offsetY float64)
+	GetMeanOffset() (float64, float64)
+	SetSeed(seed uint64)
+	Clone() AccuracyModel
+}
+
+// GaussianAccuracyModel is an accuracy model whose scatter is a normal distribution, or a weighted mix of
+// normal distributions, all centred on the target plus the mean offset.  Because the shape of such a
+// distribution is known exactly, the chance of hitting any area of the board can be calculated rather than
+// estimated by simulating throws.
+type GaussianAccuracyModel interface {
+	AccuracyModel
+	GetGaussianComponents() []GaussianComponent
+}
+
+// GaussianComponent is one normal distribution in a Gaussian accuracy model: its share of the throws, and its
+// covariance in normalized cartesian board units.  The weights of a model's components add up to 1.
+type GaussianComponent struct {
+	Weight     float64
+	VarianceX  float64
+	VarianceY  float64
+	Covariance float64
+}
+
+// SigmaEllipse describes the contour of a model's scatter at a given number of standard deviations
+// from the target, in normalized board units.  Models with the same spread in every direction
+// produce a circle, with both semi-axes equal.
+type SigmaEllipse struct {
+	SemiMajorAxis  float64
+	SemiMinorAxis  float64
+	MajorAxisAngle float64 // Degrees, clockwise from straight up, as for BoardPosition angles
+}
+
+// circularSigmaEllipse returns the degenerate ellipse - a circle - with the given radius
+func circularSigmaEllipse(radius float64) SigmaEllipse {
+	return SigmaEllipse{
+		SemiMajorAxis:  radius,
+		SemiMinorAxis:  radius,
+		MajorAxisAngle: 0,
+	}
+}
+
+// meanOffset is a model's systematic bias: the average miss vector from where the player aims to where the
+// darts actually land, in normalized cartesian board units (x to the right, y upwards).  Real players
+// consistently land a little low, or to one side, so every model is centred on target + offset rather than
+// on the target itself.  It is embedded in each model, and defaults to no offset.
+type meanOffset struct {
+	offsetX float64
+	offsetY float64
+}
+
+// SetMeanOffset sets the systematic bias of the model
+func (m *meanOffset) SetMeanOffset(offsetX float64, offsetY float64) {
+	m.offsetX = offsetX
+	m.offsetY = offsetY
+}
+
+// GetMeanOffset returns the systematic bias of the model
+func (m *meanOffset) GetMeanOffset() (float64, float64) {
+	return m.offsetX, m.offsetY
+}
+
+// offsetCentre returns the cartesian centre of the distribution of throws at the given target - the target
+// itself moved by the mean offset
+func (m *meanOffset) offsetCentre(target boardgeo.BoardPosition) (float64, float64) {
+	targetX, targetY := boardgeo.GetCartesian(target)
+	return targetX + m.offsetX, targetY + m.offsetY
 }
@@ -0,0 +1,111 @@
+package simulation
+
+// BivariateNormalAccuracyModel assumes that the result of a throw follows a two-dimensional normal distribution
+// centered on the target (moved by any systematic bias), with separate standard deviations for the horizontal
+// and vertical directions and a correlation between them.  Together these describe an arbitrary 2D covariance,
+// so the scatter of throws can be an ellipse, stretched vertically (a common pattern) or tilted, rather than
+// only a circle.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"math"
+)
+
+type BivariateNormalAccuracyModel struct {
+	meanOffset
+	standardDeviationX float64
+	standardDeviationY float64
+	correlation        float64 // -1 to 1.  Positive means darts that land right also tend to land high
+	unitNormal         distuv.Normal
+}
+
+// NewBivariateNormalAccuracyModel creates a new instance of the BivariateNormalAccuracyModel.
+// The standard deviations are in normalized board units; the correlation must be strictly between -1 and 1.
+func NewBivariateNormalAccuracyModel(stdDevX float64, stdDevY float64, correlation float64) AccuracyModel {
+	instance := &BivariateNormalAccuracyModel{
+		standardDeviationX: stdDevX,
+		standardDeviationY: stdDevY,
+		correlation:        correlation,
+		unitNormal: distuv.Normal{
+			Mu:    0.0,
+			Sigma: 1.0,
+		},
+	}
+	instance.SetSeed(randomSeed())
+	return instance
+}
+
+// SetStandardDeviation sets the horizontal standard deviation, leaving the vertical spread and the correlation unchanged
+func (p *BivariateNormalAccuracyModel) SetStandardDeviation(stdDev float64) {
+	p.standardDeviationX = stdDev
+}
+
+// SetSeed restarts the model's random numbers from the given seed
+func (p *BivariateNormalAccuracyModel) SetSeed(seed uint64) {
+	p.unitNormal.Src = rand.NewSource(seed)
+}
+
+// Clone returns a copy of the model with the same parameters and its own random number source
+func (p *BivariateNormalAccuracyModel) Clone() AccuracyModel {
+	clone := *p
+	clone.SetSeed(randomSeed())
+	return &clone
+}
+
+// GetAccuracyRadius should never be called with this instance of the accuracy model
+func (p *BivariateNormalAccuracyModel) GetAccuracyRadius() float64 {
+	panic("GetAccuracyRadius not meaningful for bivariate normal model")
+}
+
+// GetThrow generates a throw based on the bivariate normal distribution
+//
+//	Two independent unit normal values are combined so that the resulting x and y deviations have the
+//	model's standard deviations and correlation (this is the Cholesky factor of the covariance matrix)
+func (p *BivariateNormalAccuracyModel) GetThrow(target boardgeo.BoardPosition) (boardgeo.BoardPosition, error) {
+	z1 := p.unitNormal.Rand()
+	z2 := p.unitNormal.Rand()
+	deltaX := p.standardDeviationX * z1
+	deltaY := p.standardDeviationY * (p.correlation*z1 + math.Sqrt(1-p.correlation*p.correlation)*z2)
+
+	centreX, centreY := p.offsetCentre(target)
+	result := boardgeo.CreateBoardPositionFromCartesian(centreX+deltaX, centreY+deltaY)
+	return result, nil
+}
+
+// GetSigmaEllipse returns the ellipse on which the probability density is the same as at a given number of
+// standard deviations along each principal axis.  The axes of the ellipse are the eigenvectors of the
+// covariance matrix, and the semi-axes are the square roots of the corresponding eigenvalues.
+func (p *BivariateNormalAccuracyModel) GetSigmaEllipse(numSigmas float64) SigmaEllipse {
+	varianceX := p.standardDeviationX * p.standardDeviationX
+	varianceY := p.standardDeviationY * p.standardDeviationY
+	covariance := p.correlation * p.standardDeviationX * p.standardDeviationY
+
+	//	Eigenvalues of the 2x2 symmetric matrix [[varianceX, covariance], [covariance, varianceY]]
+	halfTrace := (varianceX + varianceY) / 2
+	discriminant := math.Sqrt(math.Pow((varianceX-varianceY)/2, 2) + covariance*covariance)
+	majorEigenvalue := halfTrace + discriminant
+	minorEigenvalue := math.Max(0, halfTrace-discriminant)
+
+	//	Direction of the major axis, measured anticlockwise from the x axis, converted to the
+	//	board convention of clockwise from straight up
+	majorAxisFromX := 0.5 * math.Atan2(2*covariance, varianceX-varianceY)
+	majorAxisAngle := 90 - majorAxisFromX*180/math.Pi
+
+	return SigmaEllipse{
+		SemiMajorAxis:  numSigmas * math.Sqrt(majorEigenvalue),
+		SemiMinorAxis:  numSigmas * math.Sqrt(minorEigenvalue),
+		MajorAxisAngle: majorAxisAngle,
+	}
+}
+
+// GetGaussianComponents returns the single normal distribution of the model, with its full covariance
+func (p *BivariateNormalAccuracyModel) GetGaussianComponents() []GaussianComponent {
+	return []GaussianComponent{{
+		Weight:     1,
+		VarianceX:  p.standardDeviationX * p.standardDeviationX,
+		VarianceY:  p.standardDeviationY * p.standardDeviationY,
+		Covariance: p.correlation * p.standardDeviationX * p.standardDeviationY,
+	}}
+}
@@ -0,0 +1,140 @@
+package simulation
+
+// EmpiricalAccuracyModel makes no assumption about the shape of a player's scatter.  Each simulated throw
+// re-uses the miss vector of a randomly chosen real throw (a "bootstrap" sample), so lopsided or multi-modal
+// patterns that no normal distribution captures are reproduced as recorded.  Optionally, each re-used miss is
+// jittered by a small normal "kernel", which smooths the handful of recorded points into a continuous
+// distribution - a kernel density estimate - so that the simulated darts don't pile up on the exact spots
+// that were recorded.
+//
+// The recorded miss vectors already include the player's systematic bias, so the mean offset of this model is
+// normally left at zero; any offset that is set is added on top of the recorded pattern.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"errors"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"math"
+)
+
+// ErrNoRecordedThrows is returned when an empirical model is asked for a throw but has no recorded throws to use
+var ErrNoRecordedThrows = errors.New("empirical accuracy model has no recorded throws")
+
+type EmpiricalAccuracyModel struct {
+	meanOffset
+	missVectors      []MissVector
+	smoothed         bool
+	bandwidthX       float64 // Standard deviation of the smoothing kernel on each axis
+	bandwidthY       float64
+	recordedStdDev   float64 // Pooled standard deviation of the recorded throws, before any scaling
+	scale            float64 // Multiplier applied to every miss vector (see SetStandardDeviation)
+	recordedEllipse  SigmaEllipse
+	unitNormal       distuv.Normal
+	unitUniform      distuv.Uniform
+	recordedFitError error
+}
+
+// NewEmpiricalAccuracyModel creates a new instance of the EmpiricalAccuracyModel, drawing from the given miss
+// vectors (as provided by RealThrowCollection's GetMissVectors).  If smoothed is requested, each throw is
+// jittered by a normal kernel whose width follows Silverman's rule of thumb for the number of throws recorded.
+func NewEmpiricalAccuracyModel(missVectors []MissVector, smoothed bool) AccuracyModel {
+	instance := &EmpiricalAccuracyModel{
+		missVectors: append([]MissVector(nil), missVectors...),
+		smoothed:    smoothed,
+		scale:       1.0,
+		unitNormal: distuv.Normal{
+			Mu:    0.0,
+			Sigma: 1.0,
+		},
+		unitUniform: distuv.Uniform{
+			Min: 0.0,
+			Max: 1.0,
+		},
+	}
+	fit, err := FitAccuracy(instance.missVectors)
+	instance.recordedFitError = err
+	if err == nil {
+		instance.recordedStdDev = fit.PooledStdDev()
+		if smoothed {
+			instance.bandwidthX, instance.bandwidthY = silvermanBandwidths(fit)
+		}
+		//	The spread of the model is the spread of the recorded throws about their own mean,
+		//	widened by the kernel, which adds its variance to each axis
+		widenedStdDevX := math.Sqrt(fit.StdDevX.Value*fit.StdDevX.Value + instance.bandwidthX*instance.bandwidthX)
+		widenedStdDevY := math.Sqrt(fit.StdDevY.Value*fit.StdDevY.Value + instance.bandwidthY*instance.bandwidthY)
+		correlation := math.Max(-maxFittedCorrelation, math.Min(maxFittedCorrelation, fit.Correlation.Value))
+		instance.recordedEllipse = NewBivariateNormalAccuracyModel(widenedStdDevX, widenedStdDevY, correlation).GetSigmaEllipse(1)
+	}
+	instance.SetSeed(randomSeed())
+	return instance
+}
+
+// silvermanBandwidths returns the kernel standard deviation for each axis by Silverman's rule of thumb, which is
+// close to the best width if the scatter is roughly normal.  In two dimensions the rule is sigma * n^(-1/6).
+func silvermanBandwidths(fit AccuracyFit) (float64, float64) {
+	factor := math.Pow(float64(fit.NumThrows), -1.0/6.0)
+	return fit.StdDevX.Value * factor, fit.StdDevY.Value * factor
+}
+
+// SetStandardDeviation rescales the recorded pattern so that its overall standard deviation is the one given.
+// This keeps the shape of the player's scatter, but asks how they would do if they were more (or less) accurate.
+func (p *EmpiricalAccuracyModel) SetStandardDeviation(stdDev float64) {
+	if p.recordedStdDev > 0 {
+		p.scale = stdDev / p.recordedStdDev
+	}
+}
+
+// SetSeed restarts the model's random numbers from the given seed, the one source serving both the choice
+// of recorded throw and the smoothing
+func (p *EmpiricalAccuracyModel) SetSeed(seed uint64) {
+	source := rand.NewSource(seed)
+	p.unitNormal.Src = source
+	p.unitUniform.Src = source
+}
+
+// Clone returns a copy of the model with the same parameters and its own random number source.  The recorded
+// throws are never changed, so the copy shares them.
+func (p *EmpiricalAccuracyModel) Clone() AccuracyModel {
+	clone := *p
+	clone.SetSeed(randomSeed())
+	return &clone
+}
+
+// GetAccuracyRadius should never be called with this instance of the accuracy model
+func (p *EmpiricalAccuracyModel) GetAccuracyRadius() float64 {
+	panic("GetAccuracyRadius not meaningful for empirical model")
+}
+
+// GetThrow generates a throw by re-using the miss vector of a randomly chosen recorded throw, jittered by the
+// smoothing kernel if requested
+func (p *EmpiricalAccuracyModel) GetThrow(target boardgeo.BoardPosition) (boardgeo.BoardPosition, error) {
+	if len(p.missVectors) == 0 {
+		return boardgeo.BoardPosition{}, ErrNoRecordedThrows
+	}
+	index := min(len(p.missVectors)-1, int(p.unitUniform.Rand()*float64(len(p.missVectors))))
+	miss := p.missVectors[index]
+	deltaX := miss.X
+	deltaY := miss.Y
+	if p.smoothed {
+		deltaX += p.bandwidthX * p.unitNormal.Rand()
+		deltaY += p.bandwidthY * p.unitNormal.Rand()
+	}
+
+	centreX, centreY := p.offsetCentre(target)
+	result := boardgeo.CreateBoardPositionFromCartesian(centreX+p.scale*deltaX, centreY+p.scale*deltaY)
+	return result, nil
+}
+
+// GetSigmaEllipse returns the ellipse of a bivariate normal distribution with the same spread as the recorded
+// throws.  The real scatter need not be elliptical at all, so this is only a guide to its size and direction.
+func (p *EmpiricalAccuracyModel) GetSigmaEllipse(numSigmas float64) SigmaEllipse {
+	if p.recordedFitError != nil {
+		return circularSigmaEllipse(0)
+	}
+	return SigmaEllipse{
+		SemiMajorAxis:  numSigmas * p.scale * p.recordedEllipse.SemiMajorAxis,
+		SemiMinorAxis:  numSigmas * p.scale * p.recordedEllipse.SemiMinorAxis,
+		MajorAxisAngle: p.recordedEllipse.MajorAxisAngle,
+	}
+}
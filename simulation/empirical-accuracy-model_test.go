@@ -0,0 +1,38 @@
+package simulation
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"testing"
+)
+
+// TestEmpiricalModelSeededThrowsRepeat checks that two models built from the same recorded throws, and given the
+// same seed, throw exactly the same darts, however the collection happens to order its targets
+func TestEmpiricalModelSeededThrowsRepeat(t *testing.T) {
+	collection := NewRealThrowCollectionInstance()
+	for i := 0; i < 12; i++ {
+		target := boardgeo.CreateBoardPositionFromPolar(0.1+0.07*float64(i), float64(30*i))
+		for j := 0; j < 5; j++ {
+			collection.AddHit(target, boardgeo.CreateBoardPositionFromPolar(target.Radius+0.01*float64(j), target.Angle+float64(j)))
+		}
+	}
+	for _, smooth := range []bool{false, true} {
+		first := NewEmpiricalAccuracyModel(collection.GetMissVectors(), smooth)
+		second := NewEmpiricalAccuracyModel(collection.GetMissVectors(), smooth)
+		first.SetSeed(7)
+		second.SetSeed(7)
+		target := boardgeo.CreateBoardPositionFromPolar(0.6, 0)
+		for i := 0; i < 200; i++ {
+			firstThrow, err := first.GetThrow(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			secondThrow, err := second.GetThrow(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if firstThrow != secondThrow {
+				t.Fatalf("smooth %v, throw %d: %v and %v differ", smooth, i, firstThrow, secondThrow)
+			}
+		}
+	}
+}
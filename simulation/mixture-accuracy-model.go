@@ -0,0 +1,102 @@
+package simulation
+
+// MixtureAccuracyModel assumes that most throws follow a tight normal distribution centred on the target (moved by
+// any systematic bias), but that a small fraction are "wild throws" from a much broader normal distribution around
+// the same centre.  A single normal distribution fitted to real throws gives these occasional wild darts far too
+// little weight, which makes aiming right next to the "out" region, or next to low-value segments, look cheaper
+// than it really is.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+type MixtureAccuracyModel struct {
+	meanOffset
+	coreStandardDeviation    float64
+	outlierStandardDeviation float64
+	outlierWeight            float64 // 0 to 1, the fraction of throws that are wild
+	unitNormal               distuv.Normal
+	unitUniform              distuv.Uniform
+}
+
+// NewMixtureAccuracyModel creates a new instance of the MixtureAccuracyModel.
+// The standard deviations are in normalized board units; the outlier weight is the fraction (0-1) of throws
+// drawn from the broad outlier distribution.
+func NewMixtureAccuracyModel(coreStdDev float64, outlierStdDev float64, outlierWeight float64) AccuracyModel {
+	instance := &MixtureAccuracyModel{
+		coreStandardDeviation:    coreStdDev,
+		outlierStandardDeviation: outlierStdDev,
+		outlierWeight:            outlierWeight,
+		unitNormal: distuv.Normal{
+			Mu:    0.0,
+			Sigma: 1.0,
+		},
+		unitUniform: distuv.Uniform{
+			Min: 0.0,
+			Max: 1.0,
+		},
+	}
+	instance.SetSeed(randomSeed())
+	return instance
+}
+
+// SetStandardDeviation sets the standard deviation of the core of the distribution, leaving the outliers unchanged
+func (p *MixtureAccuracyModel) SetStandardDeviation(stdDev float64) {
+	p.coreStandardDeviation = stdDev
+}
+
+// SetSeed restarts the model's random numbers from the given seed, the one source serving both the choice
+// of component and the offsets
+func (p *MixtureAccuracyModel) SetSeed(seed uint64) {
+	source := rand.NewSource(seed)
+	p.unitNormal.Src = source
+	p.unitUniform.Src = source
+}
+
+// Clone returns a copy of the model with the same parameters and its own random number source
+func (p *MixtureAccuracyModel) Clone() AccuracyModel {
+	clone := *p
+	clone.SetSeed(randomSeed())
+	return &clone
+}
+
+// GetAccuracyRadius should never be called with this instance of the accuracy model
+func (p *MixtureAccuracyModel) GetAccuracyRadius() float64 {
+	panic("GetAccuracyRadius not meaningful for mixture model")
+}
+
+// GetThrow generates a throw from the mixture
+//
+//	First decide whether this is a wild throw, with probability equal to the outlier weight, then generate
+//	normally distributed offsets with the standard deviation of the chosen component
+func (p *MixtureAccuracyModel) GetThrow(target boardgeo.BoardPosition) (boardgeo.BoardPosition, error) {
+	stdDev := p.coreStandardDeviation
+	if p.unitUniform.Rand() < p.outlierWeight {
+		stdDev = p.outlierStandardDeviation
+	}
+	deltaX := stdDev * p.unitNormal.Rand()
+	deltaY := stdDev * p.unitNormal.Rand()
+
+	centreX, centreY := p.offsetCentre(target)
+	result := boardgeo.CreateBoardPositionFromCartesian(centreX+deltaX, centreY+deltaY)
+	return result, nil
+}
+
+// GetSigmaEllipse returns the circle that lies a given number of standard deviations of the core distribution
+// from the target.  This is where the player's ordinary throws go; the wild throws are, by definition, the ones
+// that land outside it.
+func (p *MixtureAccuracyModel) GetSigmaEllipse(numSigmas float64) SigmaEllipse {
+	return circularSigmaEllipse(numSigmas * p.coreStandardDeviation)
+}
+
+// GetGaussianComponents returns the core and outlier distributions, weighted by their share of the throws
+func (p *MixtureAccuracyModel) GetGaussianComponents() []GaussianComponent {
+	coreVariance := p.coreStandardDeviation * p.coreStandardDeviation
+	outlierVariance := p.outlierStandardDeviation * p.outlierStandardDeviation
+	return []GaussianComponent{
+		{Weight: 1 - p.outlierWeight, VarianceX: coreVariance, VarianceY: coreVariance, Covariance: 0},
+		{Weight: p.outlierWeight, VarianceX: outlierVariance, VarianceY: outlierVariance, Covariance: 0},
+	}
+}
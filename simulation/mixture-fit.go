@@ -0,0 +1,169 @@
+package simulation
+
+//	Fitting the wild-throw mixture model to real throws.  There is no formula for the parameters of a mixture,
+//	so we use the Expectation-Maximization algorithm: starting from a rough guess, alternately work out how
+//	likely each throw is to be a wild one (the "expectation" step), then re-estimate the parameters treating
+//	those likelihoods as fractional memberships of the two components (the "maximization" step).  Each round
+//	improves the fit, and we stop when it no longer changes.
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// MixtureFit is the result of fitting the wild-throw mixture model to a set of miss vectors.
+// All distances are in normalized board units.
+type MixtureFit struct {
+	NumThrows     int
+	MeanOffsetX   float64
+	MeanOffsetY   float64
+	CoreStdDev    float64
+	OutlierStdDev float64
+	OutlierWeight float64
+}
+
+// ErrTooFewThrowsForMixture is returned when there are not enough throws to separate the wild throws from the rest
+var ErrTooFewThrowsForMixture = errors.New("at least 10 throws are needed to fit a wild-throw model")
+
+const minThrowsForMixture = 10
+
+// Limits that keep the fit meaningful: the outliers must be clearly broader than the core (otherwise the two
+// components are interchangeable), and must be a minority of throws
+const minOutlierSpreadRatio = 2.0
+const minOutlierWeight = 0.001
+const maxOutlierWeight = 0.5
+
+// Smallest standard deviation allowed, so that a few identical throws can't give an infinitely tight core
+const minFittedStdDev = 0.0001
+
+// The fit stops when an iteration improves the log-likelihood by less than this, or after too many iterations
+const mixtureConvergenceTolerance = 1e-9
+const maxMixtureIterations = 500
+
+// FitMixture fits the wild-throw mixture model to the given miss vectors
+//
+//	Both components are circular normal distributions with the same centre.  The starting point is a robust
+//	estimate that isn't dragged about by the wild throws: the median miss for the centre, the median distance
+//	from it for the core spread, and an assumption that a few throws are wild with a spread several times larger.
+func FitMixture(missVectors []MissVector) (MixtureFit, error) {
+	n := len(missVectors)
+	if n < minThrowsForMixture {
+		return MixtureFit{}, ErrTooFewThrowsForMixture
+	}
+
+	centreX, centreY := medianMiss(missVectors)
+	// For a circular normal distribution, the median distance from the centre is sigma * sqrt(2 ln 2)
+	coreVariance := math.Pow(math.Max(minFittedStdDev, medianDistance(missVectors, centreX, centreY)/math.Sqrt(2*math.Ln2)), 2)
+	outlierVariance := 16 * coreVariance
+	outlierWeight := 0.05
+
+	outlierResponsibility := make([]float64, n)
+	previousLogLikelihood := math.Inf(-1)
+	for iteration := 0; iteration < maxMixtureIterations; iteration++ {
+		// Expectation: the probability that each throw came from the outlier component
+		logLikelihood := 0.0
+		for i, miss := range missVectors {
+			distanceSquared := squaredDistance(miss, centreX, centreY)
+			coreDensity := (1 - outlierWeight) * circularNormalDensity(distanceSquared, coreVariance)
+			outlierDensity := outlierWeight * circularNormalDensity(distanceSquared, outlierVariance)
+			total := coreDensity + outlierDensity
+			if total > 0 {
+				outlierResponsibility[i] = outlierDensity / total
+				logLikelihood += math.Log(total)
+			} else {
+				// Too far out for either density to register - it can only be a wild throw
+				outlierResponsibility[i] = 1
+				logLikelihood += math.Log(math.SmallestNonzeroFloat64)
+			}
+		}
+		if logLikelihood-previousLogLikelihood < mixtureConvergenceTolerance {
+			break
+		}
+		previousLogLikelihood = logLikelihood
+
+		// Maximization: the weighted estimates of each parameter
+		var sumResponsibility, sumCoreSquares, sumOutlierSquares float64
+		for i, miss := range missVectors {
+			distanceSquared := squaredDistance(miss, centreX, centreY)
+			sumResponsibility += outlierResponsibility[i]
+			sumCoreSquares += (1 - outlierResponsibility[i]) * distanceSquared
+			sumOutlierSquares += outlierResponsibility[i] * distanceSquared
+		}
+		outlierWeight = math.Max(minOutlierWeight, math.Min(maxOutlierWeight, sumResponsibility/float64(n)))
+		// Each throw has two coordinates, hence the factor of 2 in the variance denominators
+		coreVariance = math.Max(minFittedStdDev*minFittedStdDev, sumCoreSquares/(2*(float64(n)-sumResponsibility)))
+		if sumResponsibility > 0 {
+			outlierVariance = sumOutlierSquares / (2 * sumResponsibility)
+		}
+		outlierVariance = math.Max(outlierVariance, minOutlierSpreadRatio*minOutlierSpreadRatio*coreVariance)
+
+		// The shared centre is the mean of the throws, each weighted by the precision of its likely component
+		var sumWeights, sumWeightedX, sumWeightedY float64
+		for i, miss := range missVectors {
+			weight := (1-outlierResponsibility[i])/coreVariance + outlierResponsibility[i]/outlierVariance
+			sumWeights += weight
+			sumWeightedX += weight * miss.X
+			sumWeightedY += weight * miss.Y
+		}
+		centreX = sumWeightedX / sumWeights
+		centreY = sumWeightedY / sumWeights
+	}
+
+	return MixtureFit{
+		NumThrows:     n,
+		MeanOffsetX:   centreX,
+		MeanOffsetY:   centreY,
+		CoreStdDev:    math.Sqrt(coreVariance),
+		OutlierStdDev: math.Sqrt(outlierVariance),
+		OutlierWeight: outlierWeight,
+	}, nil
+}
+
+// Model returns a ready-to-use mixture accuracy model with the fitted parameters, offset by the fitted bias
+func (f MixtureFit) Model() AccuracyModel {
+	model := NewMixtureAccuracyModel(f.CoreStdDev, f.OutlierStdDev, f.OutlierWeight)
+	model.SetMeanOffset(f.MeanOffsetX, f.MeanOffsetY)
+	return model
+}
+
+// circularNormalDensity is the density of a two-dimensional normal distribution with the same variance on both
+// axes and no correlation, at a point the given squared distance from its centre
+func circularNormalDensity(distanceSquared float64, variance float64) float64 {
+	return math.Exp(-distanceSquared/(2*variance)) / (2 * math.Pi * variance)
+}
+
+// squaredDistance returns the squared distance of a miss vector from the given point
+func squaredDistance(miss MissVector, centreX float64, centreY float64) float64 {
+	return (miss.X-centreX)*(miss.X-centreX) + (miss.Y-centreY)*(miss.Y-centreY)
+}
+
+// medianMiss returns the median of the x and of the y components of the miss vectors
+func medianMiss(missVectors []MissVector) (float64, float64) {
+	xs := make([]float64, len(missVectors))
+	ys := make([]float64, len(missVectors))
+	for i, miss := range missVectors {
+		xs[i] = miss.X
+		ys[i] = miss.Y
+	}
+	return median(xs), median(ys)
+}
+
+// medianDistance returns the median distance of the miss vectors from the given point
+func medianDistance(missVectors []MissVector, centreX float64, centreY float64) float64 {
+	distances := make([]float64, len(missVectors))
+	for i, miss := range missVectors {
+		distances[i] = math.Sqrt(squaredDistance(miss, centreX, centreY))
+	}
+	return median(distances)
+}
+
+// median returns the median of the given values, reordering the slice in the process
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[middle-1] + values[middle]) / 2
+	}
+	return values[middle]
+}
@@ -0,0 +1,63 @@
+package simulation
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestFitMixtureRecoversPlantedMixture checks that the EM fit, given throws from a known wild-throw model, finds
+// the parameters it was built with
+func TestFitMixtureRecoversPlantedMixture(t *testing.T) {
+	tests := []struct {
+		coreStdDev, outlierStdDev, outlierWeight float64
+		offsetX, offsetY                         float64
+	}{
+		{0.05, 0.30, 0.10, 0, 0},
+		{0.03, 0.20, 0.05, 0.02, -0.01},
+		{0.08, 0.40, 0.20, -0.03, 0.02},
+	}
+	centre := boardgeo.CreateBoardPositionFromPolar(0, 0)
+	for _, test := range tests {
+		model := NewMixtureAccuracyModel(test.coreStdDev, test.outlierStdDev, test.outlierWeight)
+		model.SetMeanOffset(test.offsetX, test.offsetY)
+		model.SetSeed(5)
+		missVectors := make([]MissVector, 0, 20000)
+		for i := 0; i < 20000; i++ {
+			hit, err := model.GetThrow(centre)
+			if err != nil {
+				t.Fatal(err)
+			}
+			x, y := boardgeo.GetCartesian(hit)
+			missVectors = append(missVectors, MissVector{X: x, Y: y})
+		}
+		fit, err := FitMixture(missVectors)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checks := []struct {
+			name      string
+			got, want float64
+			tolerance float64
+		}{
+			{"core std dev", fit.CoreStdDev, test.coreStdDev, 0.05 * test.coreStdDev},
+			{"outlier std dev", fit.OutlierStdDev, test.outlierStdDev, 0.05 * test.outlierStdDev},
+			{"outlier weight", fit.OutlierWeight, test.outlierWeight, 0.2 * test.outlierWeight},
+			{"offset x", fit.MeanOffsetX, test.offsetX, 0.005},
+			{"offset y", fit.MeanOffsetY, test.offsetY, 0.005},
+		}
+		for _, check := range checks {
+			if math.Abs(check.got-check.want) > check.tolerance {
+				t.Errorf("mixture %+v: %s %g, want %g within %g", test, check.name, check.got, check.want, check.tolerance)
+			}
+		}
+	}
+}
+
+// TestFitMixtureTooFewThrows checks that a handful of throws can't be fitted
+func TestFitMixtureTooFewThrows(t *testing.T) {
+	if _, err := FitMixture(make([]MissVector, minThrowsForMixture-1)); !errors.Is(err, ErrTooFewThrowsForMixture) {
+		t.Errorf("got error %v, want ErrTooFewThrowsForMixture", err)
+	}
+}
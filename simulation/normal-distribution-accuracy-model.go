@@ -1,16 +1,16 @@
 package simulation
 
 // NormalAccuracyModel assumes that the result of a throw follows a normal distribution, centered on the target
-// and with a given standard deviation centered on the target and with a given radius
+// (moved by any systematic bias the player has) and with a given standard deviation
 
 import (
 	boardgeo "DStratMC/board-geometry"
+	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/stat/distuv"
-	"image"
-	"math"
 )
 
 type NormalAccuracyModel struct {
+	meanOffset
 	//CEPRadius          float64 // Temporary. Eventually won't need this - just use the standard deviation
 	standardDeviation  float64
 	normalDistribution distuv.Normal
@@ -26,6 +26,7 @@ func NewNormalAccuracyModel(stdDev float64) AccuracyModel {
 			Sigma: stdDev,
 		},
 	}
+	instance.SetSeed(randomSeed())
 	return instance
 }
 
@@ -34,45 +35,54 @@ func (p *NormalAccuracyModel) SetStandardDeviation(stdDev float64) {
 	p.normalDistribution = distuv.Normal{
 		Mu:    0.0,
 		Sigma: stdDev,
+		Src:   p.normalDistribution.Src,
 	}
 }
 
+// SetSeed restarts the model's random numbers from the given seed
+func (p *NormalAccuracyModel) SetSeed(seed uint64) {
+	p.normalDistribution.Src = rand.NewSource(seed)
+}
+
+// Clone returns a copy of the model with the same parameters and its own random number source
+func (p *NormalAccuracyModel) Clone() AccuracyModel {
+	clone := *p
+	clone.SetSeed(randomSeed())
+	return &clone
+}
+
 // GetAccuracyRadius should never be called with this instance of the accuracy model
 func (p *NormalAccuracyModel) GetAccuracyRadius() float64 {
 	panic("GetAccuracyRadius not meaningful for normal model")
-	return 0.5
 }
 
 // GetThrow generates a throw based on a normal distribution
 //
 //	We are given the coordinates the player actually aimed at, and use the normal distribution to determine
 //	where the dart actually lands.
-func (p *NormalAccuracyModel) GetThrow(target boardgeo.BoardPosition,
-	scoringRadius float64,
-	squareDimension float64,
-	startPoint image.Point) (boardgeo.BoardPosition, error) {
+func (p *NormalAccuracyModel) GetThrow(target boardgeo.BoardPosition) (boardgeo.BoardPosition, error) {
 
-	// Generate normally distributed random offsets
-	randomXDeviation := p.normalDistribution.Rand()
-	randomYDeviation := p.normalDistribution.Rand()
-	deltaX := randomXDeviation * scoringRadius
-	deltaY := randomYDeviation * scoringRadius
+	// Generate normally distributed random offsets, in normalized board units
+	deltaX := p.normalDistribution.Rand()
+	deltaY := p.normalDistribution.Rand()
 
 	// Calculate the final coordinates in Cartesian form
-	targetX, targetY := boardgeo.GetXY(target, squareDimension)
-	xFinal := targetX + int(math.Round(deltaX))
-	yFinal := targetY + int(math.Round(deltaY))
-	point := image.Pt(xFinal+startPoint.X, yFinal+startPoint.Y)
-
-	result := boardgeo.CreateBoardPositionFromXY(point, squareDimension, startPoint)
+	centreX, centreY := p.offsetCentre(target)
+	result := boardgeo.CreateBoardPositionFromCartesian(centreX+deltaX, centreY+deltaY)
 	return result, nil
 }
 
-// GetSigmaRadius returns the radius of a circle that contains a given number of standard deviations
+// GetSigmaEllipse returns the circle that lies a given number of standard deviations from the target.
 // For readers not versed in statistics, the "sigma" is the standard deviation of a normal distribution.
 // For a normal distribution, 68% of the data falls within 1 sigma of the mean, 95% within 2 sigmas, and 99.7% within 3 sigmas.
 // So a "2 sigma" circle would represent the area that you would expect most darts to land.
 // A 3 sigma circle should catch almost all darts - darts outside this circle would be classified "wild throws" or "outliers".
-func (p *NormalAccuracyModel) GetSigmaRadius(numSigmas float64) float64 {
-	return numSigmas * p.standardDeviation
+func (p *NormalAccuracyModel) GetSigmaEllipse(numSigmas float64) SigmaEllipse {
+	return circularSigmaEllipse(numSigmas * p.standardDeviation)
+}
+
+// GetGaussianComponents returns the single normal distribution of the model, the same in every direction
+func (p *NormalAccuracyModel) GetGaussianComponents() []GaussianComponent {
+	variance := p.standardDeviation * p.standardDeviation
+	return []GaussianComponent{{Weight: 1, VarianceX: variance, VarianceY: variance, Covariance: 0}}
 }
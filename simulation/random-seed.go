@@ -0,0 +1,12 @@
+package simulation
+
+import (
+	"math/rand/v2"
+)
+
+// randomSeed returns an unpredictable seed, for a model that hasn't been given one.  Every model owns its own
+// random number source, seeded from here when it is created or cloned, so that models used on different threads
+// never wait on a shared, locked source.
+func randomSeed() uint64 {
+	return rand.Uint64()
+}
@@ -4,7 +4,7 @@ import (
 	boardgeo "DStratMC/board-geometry"
 	"encoding/json"
 	"fmt"
-	"math"
+	"sort"
 )
 
 type RealThrowCollection interface {
@@ -13,6 +13,10 @@ type RealThrowCollection interface {
 	GetStdDevString() string
 	IsStdDevAvailable() bool
 	CalcStdDevOfThrows() float64
+	FitAccuracyModel() (AccuracyFit, error)
+	FitMixtureModel() (MixtureFit, error)
+	CalcMeanOffsetOfThrows() (float64, float64)
+	GetMissVectors() []MissVector
 	GetJsonData() string
 	LoadStoredJsonData(content []byte)
 }
@@ -23,10 +27,21 @@ type RealThrowCollection interface {
 
 type hitsList []boardgeo.BoardPosition
 
+// MissVector is the difference between where a dart landed and where it was aimed, in normalized
+// cartesian board units (x to the right, y upwards)
+type MissVector struct {
+	X float64
+	Y float64
+}
+
 type RealThrowCollectionInstance struct {
-	targetsList  map[boardgeo.BoardPosition]hitsList
-	dataChanged  bool
-	cachedStdDev float64
+	targetsList    map[boardgeo.BoardPosition]hitsList
+	dataChanged    bool
+	cachedFit      AccuracyFit
+	cachedFitError error
+
+	cachedMixtureFit      MixtureFit
+	cachedMixtureFitError error
 }
 
 func NewRealThrowCollectionInstance() RealThrowCollection {
@@ -72,53 +87,80 @@ func (r *RealThrowCollectionInstance) IsStdDevAvailable() bool {
 	return r.GetNumThrows() >= 3
 }
 
+// CalcStdDevOfThrows returns the single standard deviation, the same in every direction, that best fits
+// the throws.  It comes from the full 2D fit of the miss vectors (see FitAccuracyModel), pooling the
+// horizontal and vertical spread, and is zero if there are too few throws to fit.
 func (r *RealThrowCollectionInstance) CalcStdDevOfThrows() float64 {
+	fit, err := r.FitAccuracyModel()
+	if err != nil {
+		return 0
+	}
+	return fit.PooledStdDev()
+}
+
+// FitAccuracyModel fits a bivariate normal distribution to the miss vectors of all the throws, giving
+// the bias, the spread on each axis, and the correlation, with confidence intervals.  The fit's Model
+// method provides a ready-to-use accuracy model.  The fit is cached until more data is added.
+func (r *RealThrowCollectionInstance) FitAccuracyModel() (AccuracyFit, error) {
+	r.refreshFits()
+	return r.cachedFit, r.cachedFitError
+}
+
+// FitMixtureModel fits the wild-throw mixture model to the miss vectors of all the throws: a tight core of
+// ordinary throws plus a broad spread of occasional wild ones.  The fit's Model method provides a ready-to-use
+// accuracy model.  The fit is cached until more data is added.
+func (r *RealThrowCollectionInstance) FitMixtureModel() (MixtureFit, error) {
+	r.refreshFits()
+	return r.cachedMixtureFit, r.cachedMixtureFitError
+}
+
+// refreshFits recalculates the cached fits if the data has changed since they were last calculated
+func (r *RealThrowCollectionInstance) refreshFits() {
 	if r.dataChanged {
 		r.dataChanged = false
-		//	We'll calculate a single overall standard deviation for all the throws
-		//  at all the targets.
-
-		//	Convert every throw to a floating point value representing the error
-		//  in the polar radius.  (We don't care about the angle)
-		//  By letting these values remain positive or negative, we will automatically
-		//  get values that are normal around a mean of zero
-		errorValues := make([]float64, 0, r.GetNumThrows())
-		for target, hits := range r.targetsList {
-			for _, hit := range hits {
-				radiusError := hit.Radius - target.Radius
-				// Multiply radius error by 2 since our code uses diameter error
-				errorValues = append(errorValues, radiusError*2)
-			}
-		}
+		missVectors := r.GetMissVectors()
+		r.cachedFit, r.cachedFitError = FitAccuracy(missVectors)
+		r.cachedMixtureFit, r.cachedMixtureFitError = FitMixture(missVectors)
+	}
+}
 
-		//fmt.Println(" errors list", errorValues)
-		// Calculate mean
-		var sum float64 = 0
-		for _, value := range errorValues {
-			sum += value
+// GetMissVectors returns the miss vector of every throw, at all targets.  The targets are taken in order of radius,
+// then angle, rather than the map's random order, so that a model seeded the same way throws the same darts.
+func (r *RealThrowCollectionInstance) GetMissVectors() []MissVector {
+	targets := make([]boardgeo.BoardPosition, 0, len(r.targetsList))
+	for target := range r.targetsList {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Radius != targets[j].Radius {
+			return targets[i].Radius < targets[j].Radius
 		}
-		meanValue := sum / float64(len(errorValues))
-		//fmt.Println("Error Values", errorValues)
-		//fmt.Println("Mean", meanValue)
-
-		// Mean is fixed at zero
-		//const meanValue float64 = 0
-
-		// Calculate the sum of the squares of the differences between each throw and the mean
-		var sumOfSquares float64 = 0
-		for _, value := range errorValues {
-			sumOfSquares += (value - meanValue) * (value - meanValue)
+		return targets[i].Angle < targets[j].Angle
+	})
+	missVectors := make([]MissVector, 0, r.GetNumThrows())
+	for _, target := range targets {
+		targetX, targetY := boardgeo.GetCartesian(target)
+		for _, hit := range r.targetsList[target] {
+			hitX, hitY := boardgeo.GetCartesian(hit)
+			missVectors = append(missVectors, MissVector{X: hitX - targetX, Y: hitY - targetY})
 		}
-		// Divide by the number of throws
-		// Take the square root of the result
-		// This is the standard deviation of the throws.
-		sigma2 := math.Sqrt(sumOfSquares / float64(len(errorValues)))
-		// But we have been measuring where darts hi in a small sample - we're going to assume
-		// this is the 95% circle, i.e. 2 sigma.  So divide by 2 to get the standard deviation
-		r.cachedStdDev = sigma2 / 2
-		return r.cachedStdDev
 	}
-	return r.cachedStdDev
+	return missVectors
+}
+
+// CalcMeanOffsetOfThrows returns the average miss vector over all the throws - the player's systematic bias,
+// suitable for an accuracy model's SetMeanOffset.  With no throws recorded there is no known bias.
+func (r *RealThrowCollectionInstance) CalcMeanOffsetOfThrows() (float64, float64) {
+	missVectors := r.GetMissVectors()
+	if len(missVectors) == 0 {
+		return 0, 0
+	}
+	var sumX, sumY float64
+	for _, miss := range missVectors {
+		sumX += miss.X
+		sumY += miss.Y
+	}
+	return sumX / float64(len(missVectors)), sumY / float64(len(missVectors))
 }
 
 // GetJsonData returns the data in the collection as a JSON stream, suitable for a file
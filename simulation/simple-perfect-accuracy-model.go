@@ -3,12 +3,12 @@ package simulation
 import (
 	boardgeo "DStratMC/board-geometry"
 	"fmt"
-	"image"
 )
 
 // PerfectAccuracyModel is a trivial implementation of the accuracy model where the result
 // of a throw always hits the target exactly - no error or variation is introduced.
 type PerfectAccuracyModel struct {
+	meanOffset
 }
 
 // NewPerfectAccuracyModel returns a new instance of the perfect accuracy model
@@ -18,26 +18,35 @@ func NewPerfectAccuracyModel() AccuracyModel {
 	return instance
 }
 
-// GetSigmaRadius should never be called with this instance of the accuracy model
-func (p PerfectAccuracyModel) GetSigmaRadius(_ float64) float64 {
-	panic("GetSigmaRadius not meaningful for perfect-accuracy model")
-	return 0
+// GetSigmaEllipse should never be called with this instance of the accuracy model
+func (p PerfectAccuracyModel) GetSigmaEllipse(_ float64) SigmaEllipse {
+	panic("GetSigmaEllipse not meaningful for perfect-accuracy model")
 }
 
 func (p PerfectAccuracyModel) SetStandardDeviation(_ float64) {
 	panic("SetStandardDeviation not meaningful for perfect-accuracy model")
 }
 
+// SetSeed does nothing, as there is no randomness in this model
+func (p PerfectAccuracyModel) SetSeed(_ uint64) {
+}
+
+// Clone returns a copy of the model
+func (p PerfectAccuracyModel) Clone() AccuracyModel {
+	return &p
+}
+
 // GetAccuracyRadius should never be called with this instance of the accuracy model
 func (p PerfectAccuracyModel) GetAccuracyRadius() float64 {
 	panic("should not have been called")
 }
 
-// GetThrow returns the target position as the result of the throw - perfect accuracy
-func (p PerfectAccuracyModel) GetThrow(target boardgeo.BoardPosition,
-	_ float64,
-	_ float64,
-	_ image.Point) (boardgeo.BoardPosition, error) {
+// GetThrow returns the target position as the result of the throw - perfect accuracy, apart from any
+// systematic bias, which moves every throw by the same amount
+func (p PerfectAccuracyModel) GetThrow(target boardgeo.BoardPosition) (boardgeo.BoardPosition, error) {
 	//fmt.Printf("PerfectAccuracyModel/GetThrow(%#v)\n", target)
-	return target, nil
+	if p.offsetX == 0 && p.offsetY == 0 {
+		return target, nil
+	}
+	return boardgeo.CreateBoardPositionFromCartesian(p.offsetCentre(target)), nil
 }
@@ -2,16 +2,17 @@ package simulation
 
 import (
 	boardgeo "DStratMC/board-geometry"
-	"image"
+	"golang.org/x/exp/rand"
 	"math"
-	"math/rand"
 )
 
 // UniformAccuracyModel assumes that the result of a throw is evenly distributed in a circle
 //	centered on the target and with a given radius
 
 type UniformAccuracyModel struct {
+	meanOffset
 	CEPRadius float64
+	random    *rand.Rand
 }
 
 // NewUniformAccuracyModel creates a new instance of the UniformAccuracyModel
@@ -19,38 +20,46 @@ func NewUniformAccuracyModel(CEPRadius float64) AccuracyModel {
 	instance := &UniformAccuracyModel{
 		CEPRadius: CEPRadius,
 	}
+	instance.SetSeed(randomSeed())
 	return instance
 }
 
 // GetAccuracyRadius returns the radius of the circle in which the throw will land
-func (p UniformAccuracyModel) GetAccuracyRadius() float64 {
+func (p *UniformAccuracyModel) GetAccuracyRadius() float64 {
 	return p.CEPRadius
 }
 
-// GetThrow returns the result of a throw, given a target position, a scoring radius, a square dimension, and a starting point
-func (p UniformAccuracyModel) GetThrow(target boardgeo.BoardPosition,
-	scoringRadius float64,
-	squareDimension float64,
-	startPoint image.Point) (boardgeo.BoardPosition, error) {
+// GetThrow returns the result of a throw at the given target position
+func (p *UniformAccuracyModel) GetThrow(target boardgeo.BoardPosition) (boardgeo.BoardPosition, error) {
 	//	Polar coordinate deviation
-	randomTheta := rand.Float64() * 2 * math.Pi
-	randomRadius := p.CEPRadius * math.Sqrt(rand.Float64()) * scoringRadius
+	randomTheta := p.random.Float64() * 2 * math.Pi
+	randomRadius := p.CEPRadius * math.Sqrt(p.random.Float64())
 	//	Convert to cartesian
-	targetX, targetY := boardgeo.GetXY(target, squareDimension)
-	newX := float64(targetX) + randomRadius*math.Cos(randomTheta)
-	newY := float64(targetY) + randomRadius*math.Sin(randomTheta)
+	centreX, centreY := p.offsetCentre(target)
+	newX := centreX + randomRadius*math.Cos(randomTheta)
+	newY := centreY + randomRadius*math.Sin(randomTheta)
 	//	Convert to board position
-	point := image.Pt(int(math.Round(newX))+startPoint.X, int(math.Round(newY))+startPoint.Y)
-	result := boardgeo.CreateBoardPositionFromXY(point, squareDimension, startPoint)
+	result := boardgeo.CreateBoardPositionFromCartesian(newX, newY)
 	return result, nil
 }
 
-// GetSigmaRadius is not meaningful for the uniform model
-func (p UniformAccuracyModel) GetSigmaRadius(_ float64) float64 {
-	panic("GetSigmaRadius not meaningful for uniform model")
-	return 0
+// SetSeed restarts the model's random numbers from the given seed
+func (p *UniformAccuracyModel) SetSeed(seed uint64) {
+	p.random = rand.New(rand.NewSource(seed))
 }
 
-func (p UniformAccuracyModel) SetStandardDeviation(_ float64) {
+// Clone returns a copy of the model with the same parameters and its own random number source
+func (p *UniformAccuracyModel) Clone() AccuracyModel {
+	clone := *p
+	clone.SetSeed(randomSeed())
+	return &clone
+}
+
+// GetSigmaEllipse is not meaningful for the uniform model
+func (p *UniformAccuracyModel) GetSigmaEllipse(_ float64) SigmaEllipse {
+	panic("GetSigmaEllipse not meaningful for uniform model")
+}
+
+func (p *UniformAccuracyModel) SetStandardDeviation(_ float64) {
 	panic("SetStandardDeviation not meaningful for uniform model")
 }
@@ -0,0 +1,211 @@
+package sweep_chart
+
+//	A line chart of expected score against standard deviation, for the curves of a sigma sweep, with vertical
+//	markers at the crossovers and at the player's own standard deviation.  The chart is described here
+//	independently of how it is drawn, so the same chart can be written as an SVG or PNG file; the user
+//	interface draws its own, interactive, version from the same curves.
+
+import (
+	"DStratMC/simulation"
+	target_search "DStratMC/target-search"
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// Default size of an exported chart, in pixels
+const DefaultChartWidth = 800
+const DefaultChartHeight = 500
+
+// Space around the plotting area, in pixels, for the title, axis labels, and legend
+const marginLeft = 70
+const marginRight = 160
+const marginTop = 40
+const marginBottom = 50
+
+// Approximate number of ticks wanted on each axis
+const numAxisTicks = 6
+
+// Colours of the curves, in order; the best-target curve is always drawn in black
+var SeriesColours = []color.RGBA{
+	{R: 214, G: 39, B: 40, A: 255},
+	{R: 31, G: 119, B: 180, A: 255},
+	{R: 44, G: 160, B: 44, A: 255},
+	{R: 255, G: 127, B: 14, A: 255},
+	{R: 148, G: 103, B: 189, A: 255},
+}
+
+var bestSeriesColour = color.RGBA{A: 255}
+var crossoverMarkerColour = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+var playerMarkerColour = color.RGBA{R: 200, G: 0, B: 200, A: 255}
+
+// Series is one line of the chart
+type Series struct {
+	Name   string
+	X      []float64
+	Y      []float64
+	Colour color.RGBA
+}
+
+// Marker is a vertical line across the chart at a given x value
+type Marker struct {
+	Label  string
+	X      float64
+	Colour color.RGBA
+	Dashed bool
+}
+
+// Chart is a complete line chart
+type Chart struct {
+	Title   string
+	XLabel  string
+	YLabel  string
+	Series  []Series
+	Markers []Marker
+}
+
+// NewSweepChart makes the chart of the score curves of a sigma sweep, with a dashed marker at each crossover and
+// a solid marker at the player's standard deviation (if it is greater than 0)
+func NewSweepChart(curves []target_search.ScoreCurve, crossovers []target_search.SigmaCrossover, playerStdDev float64) Chart {
+	chart := Chart{
+		Title:  "Expected score by accuracy",
+		XLabel: "Standard deviation",
+		YLabel: "Expected score",
+	}
+	for i, curve := range curves {
+		colour := SeriesColours[i%len(SeriesColours)]
+		if curve.Name == target_search.BestTargetCurveName {
+			colour = bestSeriesColour
+		}
+		chart.Series = append(chart.Series, Series{Name: curve.Name, X: curve.StdDevs, Y: curve.Scores, Colour: colour})
+	}
+	for _, crossover := range crossovers {
+		chart.Markers = append(chart.Markers, Marker{
+			Label:  fmt.Sprintf("%s to %s", segmentLabel(crossover.FromSegment), segmentLabel(crossover.ToSegment)),
+			X:      crossover.StdDev,
+			Colour: crossoverMarkerColour,
+			Dashed: true,
+		})
+	}
+	if playerStdDev > 0 {
+		chart.Markers = append(chart.Markers, Marker{
+			Label:  fmt.Sprintf("You (%.3f)", playerStdDev),
+			X:      playerStdDev,
+			Colour: playerMarkerColour,
+		})
+	}
+	return chart
+}
+
+// StdDevAxisLabel returns the label of the standard deviation axis for a sweep of the given model.  A sweep of the
+// model with wild throws varies only the spread of its core, leaving the wild throws as they are, so says so.
+func StdDevAxisLabel(model simulation.AccuracyModel) string {
+	if _, isMixture := model.(*simulation.MixtureAccuracyModel); isMixture {
+		return "Core standard deviation (wild throws unchanged)"
+	}
+	return "Standard deviation"
+}
+
+// segmentLabel names a segment briefly, for a marker
+func segmentLabel(segment int) string {
+	switch segment {
+	case 0:
+		return "off"
+	case 25:
+		return "bull"
+	}
+	return fmt.Sprint(segment)
+}
+
+// Bounds returns the range of each axis: the x values of the data, and from zero up to a round number above
+// the largest y value
+func (c Chart) Bounds() (float64, float64, float64, float64) {
+	xMin, xMax := math.Inf(1), math.Inf(-1)
+	yMax := 0.0
+	for _, series := range c.Series {
+		for i := range series.X {
+			xMin = math.Min(xMin, series.X[i])
+			xMax = math.Max(xMax, series.X[i])
+			yMax = math.Max(yMax, series.Y[i])
+		}
+	}
+	if math.IsInf(xMin, 1) {
+		return 0, 1, 0, 1
+	}
+	if xMax <= xMin {
+		xMax = xMin + 1
+	}
+	if yMax <= 0 {
+		yMax = 1
+	}
+	ticks := niceTicks(0, yMax, numAxisTicks)
+	return xMin, xMax, 0, math.Max(yMax, ticks[len(ticks)-1])
+}
+
+// niceTicks returns round-numbered tick positions (steps of 1, 2, or 5 times a power of ten) covering the
+// given range, about count of them
+func niceTicks(low float64, high float64, count int) []float64 {
+	rawStep := (high - low) / float64(count)
+	magnitude := math.Pow(10, math.Floor(math.Log10(rawStep)))
+	step := magnitude
+	for _, multiple := range []float64{2, 5, 10} {
+		if step >= rawStep {
+			break
+		}
+		step = multiple * magnitude
+	}
+	ticks := make([]float64, 0, count+2)
+	for tick := math.Floor(low/step) * step; tick < high+step*0.999; tick += step {
+		if tick >= low-step*1e-9 {
+			ticks = append(ticks, tick)
+		}
+	}
+	return ticks
+}
+
+// tickLabel formats a tick value with just enough decimal places for the step between ticks
+func tickLabel(value float64, ticks []float64) string {
+	decimals := 0
+	if len(ticks) > 1 {
+		decimals = max(0, int(-math.Floor(math.Log10(ticks[1]-ticks[0])+1e-9)))
+	}
+	return fmt.Sprintf("%.*f", decimals, value)
+}
+
+// plotArea converts data coordinates to pixel coordinates within a chart image of the given size
+type plotArea struct {
+	left, top, width, height float64
+	xMin, xMax, yMin, yMax   float64
+}
+
+func newPlotArea(chart Chart, width int, height int) plotArea {
+	xMin, xMax, yMin, yMax := chart.Bounds()
+	return plotArea{
+		left:   marginLeft,
+		top:    marginTop,
+		width:  float64(width - marginLeft - marginRight),
+		height: float64(height - marginTop - marginBottom),
+		xMin:   xMin, xMax: xMax, yMin: yMin, yMax: yMax,
+	}
+}
+
+func (a plotArea) pixelX(x float64) float64 {
+	return a.left + (x-a.xMin)/(a.xMax-a.xMin)*a.width
+}
+
+func (a plotArea) pixelY(y float64) float64 {
+	return a.top + (a.yMax-y)/(a.yMax-a.yMin)*a.height
+}
+
+func (a plotArea) right() float64 {
+	return a.left + a.width
+}
+
+func (a plotArea) bottom() float64 {
+	return a.top + a.height
+}
+
+// inXRange returns true if an x value lies within the plotted range, so a marker there can be drawn
+func (a plotArea) inXRange(x float64) bool {
+	return x >= a.xMin && x <= a.xMax
+}
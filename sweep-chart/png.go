@@ -0,0 +1,124 @@
+package sweep_chart
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+var gridColour = color.RGBA{R: 224, G: 224, B: 224, A: 255}
+var textColour = color.RGBA{A: 255}
+
+// Lengths of the drawn and blank parts of a dashed line, in pixels
+const dashLength = 6.0
+const dashGap = 4.0
+
+// RenderPNG draws the chart as an image of the given size in pixels.  It has the same layout as the SVG version,
+// but uses a small fixed bitmap font, so all the text is horizontal.
+func RenderPNG(chart Chart, width int, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	area := newPlotArea(chart, width, height)
+	drawText(img, chart.Title, float64(width)/2, marginTop/2, textColour, true)
+
+	//	Grid lines and tick labels
+	xTicks := niceTicks(area.xMin, area.xMax, numAxisTicks)
+	for _, tick := range xTicks {
+		if !area.inXRange(tick) {
+			continue
+		}
+		x := area.pixelX(tick)
+		drawLine(img, x, area.top, x, area.bottom(), gridColour, 1, false)
+		drawText(img, tickLabel(tick, xTicks), x, area.bottom()+16, textColour, true)
+	}
+	yTicks := niceTicks(area.yMin, area.yMax, numAxisTicks)
+	for _, tick := range yTicks {
+		y := area.pixelY(tick)
+		drawLine(img, area.left, y, area.right(), y, gridColour, 1, false)
+		label := tickLabel(tick, yTicks)
+		drawText(img, label, area.left-6-float64(textWidth(label)), y+4, textColour, false)
+	}
+	drawLine(img, area.left, area.top, area.right(), area.top, textColour, 1, false)
+	drawLine(img, area.left, area.bottom(), area.right(), area.bottom(), textColour, 1, false)
+	drawLine(img, area.left, area.top, area.left, area.bottom(), textColour, 1, false)
+	drawLine(img, area.right(), area.top, area.right(), area.bottom(), textColour, 1, false)
+
+	//	Axis labels
+	drawText(img, chart.XLabel, area.left+area.width/2, float64(height)-12, textColour, true)
+	drawText(img, chart.YLabel, 8, area.top-8, textColour, false)
+
+	//	Markers, then the curves over them
+	for i, marker := range chart.Markers {
+		if !area.inXRange(marker.X) {
+			continue
+		}
+		x := area.pixelX(marker.X)
+		drawLine(img, x, area.top, x, area.bottom(), marker.Colour, 1.5, marker.Dashed)
+		//	Stagger the labels down the chart, as neighbouring markers may be close together
+		drawText(img, marker.Label, x+4, area.top+14+float64(i%4)*14, marker.Colour, false)
+	}
+	for _, series := range chart.Series {
+		for i := 1; i < len(series.X); i++ {
+			drawLine(img, area.pixelX(series.X[i-1]), area.pixelY(series.Y[i-1]),
+				area.pixelX(series.X[i]), area.pixelY(series.Y[i]), series.Colour, 2, false)
+		}
+	}
+
+	//	Legend, to the right of the plotting area
+	for i, series := range chart.Series {
+		y := area.top + 10 + float64(i)*20
+		drawLine(img, area.right()+12, y, area.right()+36, y, series.Colour, 2, false)
+		drawText(img, series.Name, area.right()+42, y+4, textColour, false)
+	}
+	return img
+}
+
+// WritePNG writes the chart as a PNG image of the given size in pixels
+func WritePNG(w io.Writer, chart Chart, width int, height int) error {
+	return png.Encode(w, RenderPNG(chart, width, height))
+}
+
+// drawLine draws a straight line of the given width, by stamping a square of that width at every half pixel along it
+func drawLine(img *image.RGBA, x0 float64, y0 float64, x1 float64, y1 float64, colour color.RGBA, width float64, dashed bool) {
+	length := math.Hypot(x1-x0, y1-y0)
+	steps := max(1, int(math.Ceil(length*2)))
+	half := width / 2
+	for step := 0; step <= steps; step++ {
+		distance := float64(step) / float64(steps) * length
+		if dashed && math.Mod(distance, dashLength+dashGap) > dashLength {
+			continue
+		}
+		x := x0 + (x1-x0)*float64(step)/float64(steps)
+		y := y0 + (y1-y0)*float64(step)/float64(steps)
+		for py := int(math.Floor(y - half + 0.5)); py < int(math.Floor(y+half+0.5)); py++ {
+			for px := int(math.Floor(x - half + 0.5)); px < int(math.Floor(x+half+0.5)); px++ {
+				img.SetRGBA(px, py, colour)
+			}
+		}
+	}
+}
+
+// drawText draws text with its baseline at y, starting at x or, if centred, centred on x
+func drawText(img *image.RGBA, text string, x float64, y float64, colour color.RGBA, centred bool) {
+	if centred {
+		x -= float64(textWidth(text)) / 2
+	}
+	drawer := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(colour),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(int(math.Round(x)), int(math.Round(y))),
+	}
+	drawer.DrawString(text)
+}
+
+// textWidth returns the width of text in pixels
+func textWidth(text string) int {
+	return font.MeasureString(basicfont.Face7x13, text).Round()
+}
@@ -0,0 +1,36 @@
+package sweep_chart
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnknownChartFormat is returned when a chart is to be saved to a file whose name ends in neither .svg nor .png
+var ErrUnknownChartFormat = errors.New("chart file name must end in .svg or .png")
+
+// SaveChart writes the chart, at the default size, to the given file, as SVG or PNG according to the file's extension
+func SaveChart(filePath string, chart Chart) error {
+	extension := strings.ToLower(filepath.Ext(filePath))
+	if extension != ".svg" && extension != ".png" {
+		return ErrUnknownChartFormat
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("unable to create chart file: %w", err)
+	}
+	if extension == ".svg" {
+		err = WriteSVG(file, chart, DefaultChartWidth, DefaultChartHeight)
+	} else {
+		err = WritePNG(file, chart, DefaultChartWidth, DefaultChartHeight)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write chart: %w", err)
+	}
+	return nil
+}
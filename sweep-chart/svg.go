@@ -0,0 +1,86 @@
+package sweep_chart
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// WriteSVG writes the chart as an SVG image of the given size in pixels
+func WriteSVG(w io.Writer, chart Chart, width int, height int) error {
+	area := newPlotArea(chart, width, height)
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="middle" font-size="16">%s</text>`+"\n",
+		width/2, marginTop/2+6, html.EscapeString(chart.Title))
+
+	//	Grid lines and tick labels
+	xTicks := niceTicks(area.xMin, area.xMax, numAxisTicks)
+	for _, tick := range xTicks {
+		if !area.inXRange(tick) {
+			continue
+		}
+		x := area.pixelX(tick)
+		fmt.Fprintf(&svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e0e0e0"/>`+"\n", x, area.top, x, area.bottom())
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", x, area.bottom()+16, tickLabel(tick, xTicks))
+	}
+	yTicks := niceTicks(area.yMin, area.yMax, numAxisTicks)
+	for _, tick := range yTicks {
+		y := area.pixelY(tick)
+		fmt.Fprintf(&svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e0e0e0"/>`+"\n", area.left, y, area.right(), y)
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="end">%s</text>`+"\n", area.left-6, y+4, tickLabel(tick, yTicks))
+	}
+	fmt.Fprintf(&svg, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="none" stroke="black"/>`+"\n",
+		area.left, area.top, area.width, area.height)
+
+	//	Axis labels
+	fmt.Fprintf(&svg, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n",
+		area.left+area.width/2, height-10, html.EscapeString(chart.XLabel))
+	fmt.Fprintf(&svg, `<text transform="translate(16 %.1f) rotate(-90)" text-anchor="middle">%s</text>`+"\n",
+		area.top+area.height/2, html.EscapeString(chart.YLabel))
+
+	//	Markers, then the curves over them
+	for _, marker := range chart.Markers {
+		if !area.inXRange(marker.X) {
+			continue
+		}
+		x := area.pixelX(marker.X)
+		dash := ""
+		if marker.Dashed {
+			dash = ` stroke-dasharray="6 4"`
+		}
+		fmt.Fprintf(&svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="1.5"%s/>`+"\n",
+			x, area.top, x, area.bottom(), svgColour(marker.Colour), dash)
+		fmt.Fprintf(&svg, `<text transform="translate(%.1f %.1f) rotate(-90)" fill="%s">%s</text>`+"\n",
+			x-4, area.top+4, svgColour(marker.Colour), html.EscapeString(marker.Label))
+	}
+	for _, series := range chart.Series {
+		points := make([]string, 0, len(series.X))
+		for i := range series.X {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", area.pixelX(series.X[i]), area.pixelY(series.Y[i])))
+		}
+		fmt.Fprintf(&svg, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n",
+			strings.Join(points, " "), svgColour(series.Colour))
+	}
+
+	//	Legend, to the right of the plotting area
+	for i, series := range chart.Series {
+		y := area.top + 10 + float64(i)*20
+		fmt.Fprintf(&svg, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2"/>`+"\n",
+			area.right()+12, y, area.right()+36, y, svgColour(series.Colour))
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f">%s</text>`+"\n", area.right()+42, y+4, html.EscapeString(series.Name))
+	}
+
+	svg.WriteString("</svg>\n")
+	_, err := io.WriteString(w, svg.String())
+	return err
+}
+
+// svgColour formats a colour for an SVG attribute
+func svgColour(colour color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", colour.R, colour.G, colour.B)
+}
@@ -0,0 +1,201 @@
+package target_search
+
+//	The adaptive search spends a fixed budget of throws more wisely than the full grid search.  A coarse pass
+//	over the whole board finds the promising areas; then each refinement round takes the best few targets so
+//	far, and searches the small neighbourhood around each at half the previous spacing, with many more throws
+//	per target.  Most of the board scores poorly for any player, so little is spent there, and the answer near
+//	the best targets is both more finely placed and more precisely measured than a full grid search of the same
+//	cost would give.
+//	Each pass is an ordinary run of the search engine, with the targets for that pass, and the results of all
+//	the passes are merged, pooling the throws when a target is scored again.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	"context"
+	"math"
+)
+
+// DefaultAdaptiveThrowBudget is a total number of throws that gives a good search in a few seconds
+const DefaultAdaptiveThrowBudget = 10_000_000
+
+// Spacing of the targets in the coarse pass over the whole board
+const adaptiveCoarseRadiusStep = 0.1
+const adaptiveCoarseAngleStep = 6.0
+
+// Fraction of the budget spent on the coarse pass; the rest is shared equally among the refinement rounds
+const adaptiveCoarseBudgetFraction = 0.4
+
+// Number of refinement rounds, each halving the spacing of the previous
+const adaptiveRefinementRounds = 3
+
+// Number of the best targets so far whose neighbourhoods are searched in each refinement round
+const adaptiveCandidatesPerRound = 10
+
+// AdaptiveSearchEngineInstance is the data for a coarse-to-fine search with a budget of throws
+type AdaptiveSearchEngineInstance struct {
+	model         simulation.AccuracyModel
+	throwBudget   int64
+	numWorkers    int
+	scoringMethod ScoringMethod
+	commonRandom  bool
+	seed          uint64
+	seeded        bool
+	chunkSize     int
+}
+
+// NewAdaptiveSearchEngine creates a search engine that throws a total of throwBudget darts, using the given
+// accuracy model, first over the whole board and then around the best targets found, spread across numWorkers threads
+func NewAdaptiveSearchEngine(model simulation.AccuracyModel, throwBudget int64, numWorkers int) SearchEngine {
+	instance := &AdaptiveSearchEngineInstance{
+		model:         model,
+		throwBudget:   throwBudget,
+		numWorkers:    numWorkers,
+		scoringMethod: ScoringMethod_MonteCarlo,
+	}
+	return instance
+}
+
+// SetScoringMethod sets how the average score at each target is found, as for the grid search.  With a method
+// that doesn't throw darts, the budget only matters in that the targets are still refined around the best.
+func (e *AdaptiveSearchEngineInstance) SetScoringMethod(method ScoringMethod) {
+	e.scoringMethod = method
+}
+
+// SetCommonRandomNumbers sets whether the same set of darts is thrown at every target in each round, as for the
+// grid search.  Each pass samples its own set.
+func (e *AdaptiveSearchEngineInstance) SetCommonRandomNumbers(common bool) {
+	e.commonRandom = common
+}
+
+// SetSeed makes the search repeatable, as for the grid search.  Each pass is seeded differently, so that a
+// target scored again in a later pass gets new throws.
+func (e *AdaptiveSearchEngineInstance) SetSeed(seed uint64) {
+	e.seed = seed
+	e.seeded = true
+}
+
+// SetChunkSize sets the number of targets handed to a worker at a time, as for the grid search
+func (e *AdaptiveSearchEngineInstance) SetChunkSize(size int) {
+	e.chunkSize = size
+}
+
+// Run performs the coarse pass and the refinement rounds, and returns the merged results.  The progress callback,
+// if not nil, is called as each target's result arrives, with the fraction of the whole budget spent.  If the
+// context is cancelled, or a throw fails, the search stops and the results so far are returned with the error.
+func (e *AdaptiveSearchEngineInstance) Run(ctx context.Context, progress ProgressCallback) (SimResults, error) {
+	results := NewSimResults()
+
+	//	Coarse pass over the whole board
+	coarseTargets := allTargets(NewTargetSupplierWithIncrements(adaptiveCoarseRadiusStep, adaptiveCoarseAngleStep))
+	coarseBudget := float64(e.throwBudget) * adaptiveCoarseBudgetFraction
+	err := e.runPass(ctx, 0, results, coarseTargets, coarseBudget, 0, adaptiveCoarseBudgetFraction, progress)
+	if err != nil {
+		return results, err
+	}
+
+	//	Refinement rounds around the best targets so far
+	roundFraction := (1 - adaptiveCoarseBudgetFraction) / adaptiveRefinementRounds
+	radiusStep := adaptiveCoarseRadiusStep
+	angleStep := adaptiveCoarseAngleStep
+	for round := 0; round < adaptiveRefinementRounds; round++ {
+		radiusStep /= 2
+		angleStep /= 2
+		targets := neighbourhoodTargets(bestCandidates(results, adaptiveCandidatesPerRound), radiusStep, angleStep)
+		startFraction := adaptiveCoarseBudgetFraction + float64(round)*roundFraction
+		err = e.runPass(ctx, round+1, results, targets, float64(e.throwBudget)*roundFraction, startFraction, roundFraction, progress)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// runPass scores the given targets, sharing the pass's budget of throws equally among them, and merges the
+// results into those of the whole search.  Pass 0 is the coarse pass, and the refinement rounds follow.
+// Progress is reported as a fraction of the whole search: the pass starts at startFraction and accounts for
+// passFraction of it.
+func (e *AdaptiveSearchEngineInstance) runPass(ctx context.Context,
+	pass int,
+	results SimResults,
+	targets []boardgeo.BoardPosition,
+	passBudget float64,
+	startFraction float64,
+	passFraction float64,
+	progress ProgressCallback) error {
+	throwsPerTarget := int32(max(1, math.Min(math.MaxInt32, passBudget/float64(len(targets)))))
+	engine := NewSearchEngine(e.model, NewListTargetSupplier(targets), throwsPerTarget, e.numWorkers)
+	engine.SetScoringMethod(e.scoringMethod)
+	engine.SetCommonRandomNumbers(e.commonRandom)
+	engine.SetChunkSize(e.chunkSize)
+	if e.seeded {
+		engine.SetSeed(deriveSeed(e.seed, uint64(pass)))
+	}
+	passResults, err := engine.Run(ctx, func(fractionComplete float64, target boardgeo.BoardPosition) {
+		if progress != nil {
+			progress(startFraction+fractionComplete*passFraction, target)
+		}
+	})
+	for _, result := range passResults.GetResultsSlice() {
+		results.MergeTargetResult(TargetResult{
+			Position:  result.Position,
+			Score:     result.Score,
+			Variance:  result.Variance,
+			NumThrows: result.NumThrows,
+		})
+	}
+	return err
+}
+
+// allTargets returns every target from a supplier
+func allTargets(supplier TargetSupplier) []boardgeo.BoardPosition {
+	targets := make([]boardgeo.BoardPosition, 0, supplier.ForecastNumTargets())
+	for supplier.HasNext() {
+		targets = append(targets, supplier.NextTarget())
+	}
+	return targets
+}
+
+// bestCandidates returns the positions of the best results so far, taking only the best of each named target
+// (e.g. "treble 19") so that the refinement explores several different areas rather than one
+func bestCandidates(results SimResults, count int) []boardgeo.BoardPosition {
+	best := FilterToOneTargetEach(results.GetResultsSortedByHighScore())
+	candidates := make([]boardgeo.BoardPosition, 0, count)
+	for i := 0; i < len(best) && i < count; i++ {
+		candidates = append(candidates, best[i].Position)
+	}
+	return candidates
+}
+
+// neighbourhoodTargets returns each candidate and the eight targets around it at the given spacing in radius
+// and angle, without duplicates and without going outside the scoring area
+func neighbourhoodTargets(candidates []boardgeo.BoardPosition, radiusStep float64, angleStep float64) []boardgeo.BoardPosition {
+	targets := make([]boardgeo.BoardPosition, 0, len(candidates)*9)
+	used := make(map[boardgeo.BoardPosition]bool, cap(targets))
+	for _, candidate := range candidates {
+		for _, radiusChange := range []float64{-radiusStep, 0, radiusStep} {
+			radius := AddWithoutNoise(candidate.Radius, radiusChange)
+			if radius < 0 || radius > 1 {
+				continue
+			}
+			for _, angleChange := range []float64{-angleStep, 0, angleStep} {
+				angle := 0.0
+				if radius > 0 {
+					//	Angle is meaningless at the centre, so all the centre targets are one
+					angle = AddWithoutNoise(candidate.Angle, angleChange)
+					if angle < 0 {
+						angle += 360
+					} else if angle >= 360 {
+						angle -= 360
+					}
+				}
+				target := boardgeo.CreateBoardPositionFromPolar(radius, angle)
+				if !used[target] {
+					used[target] = true
+					targets = append(targets, target)
+				}
+			}
+		}
+	}
+	return targets
+}
@@ -0,0 +1,52 @@
+package target_search
+
+//	When a player has a systematic bias (their darts land, on average, a little away from where they aim),
+//	the best place to aim is not the place they want the darts to land.  These functions describe an aim
+//	point in the way a player would think about it: "aim slightly above treble 19".
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Corrections smaller than this are not worth telling a player about
+const minimumCorrectionMillimetres = 1.0
+
+// DescribeAimCorrection describes an aim point, given the player's mean offset (normalized cartesian units),
+// by naming the board area the darts will be centred on and how far the aim must be moved from there to
+// allow for the bias, e.g. "Treble 19 (aim 8mm high, 3mm left)"
+func DescribeAimCorrection(aim boardgeo.BoardPosition, offsetX float64, offsetY float64) string {
+	aimX, aimY := boardgeo.GetCartesian(aim)
+	landingCentre := boardgeo.CreateBoardPositionFromCartesian(aimX+offsetX, aimY+offsetY)
+	_, _, description := boardgeo.DescribeBoardPoint(landingCentre)
+	correction := DescribeOffset(-offsetX, -offsetY)
+	if correction == "" {
+		return description
+	}
+	return fmt.Sprintf("%s (aim %s)", description, correction)
+}
+
+// DescribeOffset describes a cartesian offset (normalized units) in millimetres and plain directions, such
+// as "8mm high, 3mm left".  Components too small to matter are omitted, so no offset gives an empty string.
+func DescribeOffset(offsetX float64, offsetY float64) string {
+	parts := make([]string, 0, 2)
+	verticalMillimetres := offsetY * boardgeo.ScoringAreaRadiusMillimetres
+	if math.Abs(verticalMillimetres) >= minimumCorrectionMillimetres {
+		direction := "high"
+		if verticalMillimetres < 0 {
+			direction = "low"
+		}
+		parts = append(parts, fmt.Sprintf("%.0fmm %s", math.Abs(verticalMillimetres), direction))
+	}
+	horizontalMillimetres := offsetX * boardgeo.ScoringAreaRadiusMillimetres
+	if math.Abs(horizontalMillimetres) >= minimumCorrectionMillimetres {
+		direction := "right"
+		if horizontalMillimetres < 0 {
+			direction = "left"
+		}
+		parts = append(parts, fmt.Sprintf("%.0fmm %s", math.Abs(horizontalMillimetres), direction))
+	}
+	return strings.Join(parts, ", ")
+}
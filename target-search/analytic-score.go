@@ -0,0 +1,209 @@
+package target_search
+
+//	Analytic scoring calculates the expected score at a target exactly, rather than estimating it by
+//	simulating throws.  For a Gaussian accuracy model the probability density of where the dart lands is
+//	known, so the chance of hitting each scoring region is the integral of that density over the region,
+//	and the expected score is the sum of each region's score times the chance of hitting it.  There is no
+//	sampling noise, so neighbouring targets can be compared precisely, and it is much faster than throwing
+//	thousands of darts at every target.
+//
+//	The scoring regions are parts of rings, so the integral is done in polar coordinates, by Gauss-Legendre
+//	quadrature.  A single quadrature over a large region would miss the peak of a narrow distribution, so
+//	each region is divided into cells no larger than 1.5 standard deviations, and only the part of the region
+//	within a few standard deviations of the centre of the distribution (where all but a negligible fraction
+//	of the probability lies) is integrated.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	"gonum.org/v1/gonum/integrate/quad"
+	"math"
+)
+
+// Regions further than this many standard deviations from the centre of the distribution are ignored
+const analyticClipSigmas = 6.0
+
+// Largest cell, in standard deviations, integrated by a single quadrature
+const analyticMaxCellSigmas = 1.5
+
+// Number of Gauss-Legendre points in each direction across each cell
+const analyticQuadraturePoints = 5
+
+var scoringRegions = boardgeo.ScoringRegions()
+
+// Gauss-Legendre nodes and weights on the interval -1 to 1, shared by every integration
+var legendreNodes, legendreWeights = legendreRule(analyticQuadraturePoints)
+
+func legendreRule(n int) ([]float64, []float64) {
+	nodes := make([]float64, n)
+	weights := make([]float64, n)
+	quad.Legendre{}.FixedLocations(nodes, weights, -1, 1)
+	return nodes, weights
+}
+
+// RegionProbabilities returns the probability of hitting each region in boardgeo.ScoringRegions() when aiming
+// at the given target.  One minus their sum is the probability of the dart landing outside the scoring area.
+func RegionProbabilities(target boardgeo.BoardPosition, model simulation.GaussianAccuracyModel) []float64 {
+	probabilities := make([]float64, len(scoringRegions))
+	targetX, targetY := boardgeo.GetCartesian(target)
+	offsetX, offsetY := model.GetMeanOffset()
+	for _, component := range model.GetGaussianComponents() {
+		if component.Weight <= 0 {
+			continue
+		}
+		determinant := component.VarianceX*component.VarianceY - component.Covariance*component.Covariance
+		if determinant <= 0 {
+			//	No spread (in at least one direction): the darts all land on a point, or a line through it,
+			//	which we treat as the point
+			if i, found := regionIndexContaining(targetX+offsetX, targetY+offsetY); found {
+				probabilities[i] += component.Weight
+			}
+			continue
+		}
+		density := newGaussianDensity(targetX+offsetX, targetY+offsetY, component)
+		for i, region := range scoringRegions {
+			probabilities[i] += component.Weight * density.integrateOverRegion(region)
+		}
+	}
+	return probabilities
+}
+
+// ExpectedScore returns the average score of a dart aimed at the given target, calculated exactly from the
+// model's distribution rather than by simulating throws
+func ExpectedScore(target boardgeo.BoardPosition, model simulation.GaussianAccuracyModel) float64 {
+	expected := 0.0
+	for i, probability := range RegionProbabilities(target, model) {
+		expected += probability * float64(scoringRegions[i].Score)
+	}
+	return expected
+}
+
+// regionIndexContaining returns the index in scoringRegions of the region containing the given cartesian point,
+// or false if the point is outside the scoring area
+func regionIndexContaining(x float64, y float64) (int, bool) {
+	radius := math.Hypot(x, y)
+	angle := math.Atan2(x, y) * 180 / math.Pi
+	for i, region := range scoringRegions {
+		if radius < region.InnerRadius || radius >= region.OuterRadius {
+			continue
+		}
+		for _, shift := range []float64{-360, 0, 360} {
+			if angle+shift >= region.StartAngle && angle+shift < region.EndAngle {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// gaussianDensity is a two-dimensional normal distribution prepared for repeated evaluation
+type gaussianDensity struct {
+	centreX        float64
+	centreY        float64
+	inverseXX      float64 // Elements of the inverse of the covariance matrix
+	inverseYY      float64
+	inverseXY      float64
+	normalization  float64
+	largestStdDev  float64 // Along the major axis of the distribution
+	smallestStdDev float64 // Along the minor axis
+}
+
+func newGaussianDensity(centreX float64, centreY float64, component simulation.GaussianComponent) gaussianDensity {
+	determinant := component.VarianceX*component.VarianceY - component.Covariance*component.Covariance
+	halfTrace := (component.VarianceX + component.VarianceY) / 2
+	discriminant := math.Sqrt(math.Pow((component.VarianceX-component.VarianceY)/2, 2) + component.Covariance*component.Covariance)
+	return gaussianDensity{
+		centreX:        centreX,
+		centreY:        centreY,
+		inverseXX:      component.VarianceY / determinant,
+		inverseYY:      component.VarianceX / determinant,
+		inverseXY:      -component.Covariance / determinant,
+		normalization:  1 / (2 * math.Pi * math.Sqrt(determinant)),
+		largestStdDev:  math.Sqrt(halfTrace + discriminant),
+		smallestStdDev: math.Sqrt(math.Max(halfTrace-discriminant, 0)),
+	}
+}
+
+// at returns the probability density at the given cartesian point
+func (d gaussianDensity) at(x float64, y float64) float64 {
+	dx := x - d.centreX
+	dy := y - d.centreY
+	exponent := d.inverseXX*dx*dx + 2*d.inverseXY*dx*dy + d.inverseYY*dy*dy
+	if exponent > analyticClipSigmas*analyticClipSigmas {
+		//	Beyond the clipping distance in the narrow direction of the distribution, where the density is negligible
+		return 0
+	}
+	return d.normalization * math.Exp(-exponent/2)
+}
+
+// integrateOverRegion returns the probability of landing in the given region
+//
+//	Only the window of the region within analyticClipSigmas of the centre is integrated: a band of radii
+//	either side of the centre's radius and, if the centre is far enough from the middle of the board,
+//	a band of angles either side of the centre's angle.
+func (d gaussianDensity) integrateOverRegion(region boardgeo.ScoringRegion) float64 {
+	reach := analyticClipSigmas * d.largestStdDev
+	centreRadius := math.Hypot(d.centreX, d.centreY)
+	innerRadius := math.Max(region.InnerRadius, centreRadius-reach)
+	outerRadius := math.Min(region.OuterRadius, centreRadius+reach)
+	if innerRadius >= outerRadius {
+		return 0
+	}
+	if centreRadius <= reach || region.EndAngle-region.StartAngle >= 360 {
+		return d.integrateOverCells(innerRadius, outerRadius, region.StartAngle, region.EndAngle)
+	}
+
+	//	The window of angles may wrap around past 0 or 360 degrees relative to the region's own range,
+	//	so try it at each of the three equivalent positions
+	centreAngle := math.Atan2(d.centreX, d.centreY) * 180 / math.Pi
+	halfWidth := math.Asin(reach/centreRadius) * 180 / math.Pi
+	total := 0.0
+	for _, shift := range []float64{-360, 0, 360} {
+		startAngle := math.Max(region.StartAngle, centreAngle-halfWidth+shift)
+		endAngle := math.Min(region.EndAngle, centreAngle+halfWidth+shift)
+		if startAngle < endAngle {
+			total += d.integrateOverCells(innerRadius, outerRadius, startAngle, endAngle)
+		}
+	}
+	return total
+}
+
+// integrateOverCells integrates the density over part of a ring, divided into cells small enough, compared to
+// the distribution's narrowest spread, for the quadrature to be accurate.  Every cell has the same Gauss-Legendre
+// points, so the quadrature points of the whole window are laid out once along each direction, and the sum runs
+// over every pairing of a radius with an angle.  In polar coordinates the area element is r dr dθ, hence the
+// extra factor of the radius in the radius weights.
+func (d gaussianDensity) integrateOverCells(innerRadius float64, outerRadius float64, startAngle float64, endAngle float64) float64 {
+	startRadians := startAngle * math.Pi / 180
+	endRadians := endAngle * math.Pi / 180
+	cellSize := analyticMaxCellSigmas * d.smallestStdDev
+	numRadiusCells := max(1, int(math.Ceil((outerRadius-innerRadius)/cellSize)))
+	numAngleCells := max(1, int(math.Ceil(outerRadius*(endRadians-startRadians)/cellSize)))
+	radiusStep := (outerRadius - innerRadius) / float64(numRadiusCells)
+	angleStep := (endRadians - startRadians) / float64(numAngleCells)
+
+	radii := make([]float64, 0, numRadiusCells*len(legendreNodes))
+	radiusWeights := make([]float64, 0, cap(radii))
+	for i := 0; i < numRadiusCells; i++ {
+		cellInner := innerRadius + float64(i)*radiusStep
+		for k, node := range legendreNodes {
+			radius := cellInner + (node+1)*radiusStep/2
+			radii = append(radii, radius)
+			radiusWeights = append(radiusWeights, legendreWeights[k]*radiusStep/2*radius)
+		}
+	}
+
+	total := 0.0
+	for j := 0; j < numAngleCells; j++ {
+		cellStart := startRadians + float64(j)*angleStep
+		for k, node := range legendreNodes {
+			//	Angles are clockwise from straight up, so x is the sine and y the cosine
+			sine, cosine := math.Sincos(cellStart + (node+1)*angleStep/2)
+			angleWeight := legendreWeights[k] * angleStep / 2
+			for i, radius := range radii {
+				total += angleWeight * radiusWeights[i] * d.at(radius*sine, radius*cosine)
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,54 @@
+package target_search
+
+import (
+	"DStratMC/simulation"
+	"math"
+	"testing"
+)
+
+// TestExpectedScoreMatchesMonteCarlo checks the exact expected score at treble 20 against the average of many
+// simulated throws, for each kind of Gaussian model, allowing four standard errors of the simulated average
+func TestExpectedScoreMatchesMonteCarlo(t *testing.T) {
+	treble20 := RegionCentre(20, 3)
+	biased := simulation.NewNormalAccuracyModel(0.08)
+	biased.SetMeanOffset(0.02, -0.03)
+	tests := []struct {
+		name  string
+		model simulation.AccuracyModel
+	}{
+		{"normal 0.03", simulation.NewNormalAccuracyModel(0.03)},
+		{"normal 0.15", simulation.NewNormalAccuracyModel(0.15)},
+		{"normal 0.08 with bias", biased},
+		{"bivariate", simulation.NewBivariateNormalAccuracyModel(0.05, 0.1, 0.3)},
+		{"wild throws", simulation.NewMixtureAccuracyModel(0.06, 0.3, 0.1)},
+	}
+	for _, test := range tests {
+		exact := ExpectedScore(treble20, test.model.(simulation.GaussianAccuracyModel))
+		test.model.SetSeed(3)
+		simulated, err := ThrowsAtTargetWithVariance(treble20, test.model, 200000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdError := math.Sqrt(simulated.Variance / float64(simulated.NumThrows))
+		if math.Abs(exact-simulated.Score) > 4*stdError {
+			t.Errorf("%s: exact score %.4f, simulated %.4f with standard error %.4f", test.name, exact, simulated.Score, stdError)
+		}
+	}
+}
+
+// TestExpectedScoreSmallSpread checks that a nearly perfect player aiming at the middle of treble 20 scores 60,
+// and that the chance of landing somewhere on the board is then 1
+func TestExpectedScoreSmallSpread(t *testing.T) {
+	model := simulation.NewNormalAccuracyModel(0.001).(simulation.GaussianAccuracyModel)
+	treble20 := RegionCentre(20, 3)
+	if score := ExpectedScore(treble20, model); math.Abs(score-60) > 1e-6 {
+		t.Errorf("got expected score %g, want 60", score)
+	}
+	total := 0.0
+	for _, probability := range RegionProbabilities(treble20, model) {
+		total += probability
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("region probabilities add up to %g, want 1", total)
+	}
+}
@@ -0,0 +1,160 @@
+package target_search
+
+//	The successive-halving search treats the targets like the arms of a bandit problem: rather than giving
+//	every target the same number of throws, it starts every target with a few throws, then repeatedly drops
+//	the worse half and doubles the throws at the survivors.  Each round costs about the same, so most of the
+//	throws go to the few targets that are still contenders, where precision matters, and few are wasted on
+//	targets that are obviously poor.  The search ends when the best target is significantly better than all
+//	the other survivors, or after a fixed number of rounds, since neighbouring targets may never be separable.
+//	Each round is an ordinary run of the search engine on the survivors, and its results are merged into the
+//	results of the whole search, pooling the throws of each target across the rounds.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	"context"
+	"math"
+)
+
+// Halving stops when this few targets survive; further rounds add throws to them all
+const halvingMinSurvivors = 8
+
+// The search always ends after this many rounds
+const halvingMaxRounds = 14
+
+// HalvingSearchEngineInstance is the data for a successive-halving search
+type HalvingSearchEngineInstance struct {
+	model         simulation.AccuracyModel
+	supplier      TargetSupplier
+	initialThrows int32
+	numWorkers    int
+	scoringMethod ScoringMethod
+	commonRandom  bool
+	seed          uint64
+	seeded        bool
+	chunkSize     int
+}
+
+// NewHalvingSearchEngine creates a search engine that throws initialThrows darts, using the given accuracy model,
+// at every target from the supplier, and then doubles the throws at the better half of the targets round after
+// round, spread across numWorkers threads
+func NewHalvingSearchEngine(model simulation.AccuracyModel,
+	supplier TargetSupplier,
+	initialThrows int32,
+	numWorkers int) SearchEngine {
+	instance := &HalvingSearchEngineInstance{
+		model:         model,
+		supplier:      supplier,
+		initialThrows: initialThrows,
+		numWorkers:    numWorkers,
+		scoringMethod: ScoringMethod_MonteCarlo,
+	}
+	return instance
+}
+
+// SetScoringMethod sets how the average score at each target is found.  Only Monte-Carlo scores have any
+// sampling error to reduce, so with the other methods the search is a single round.
+func (e *HalvingSearchEngineInstance) SetScoringMethod(method ScoringMethod) {
+	e.scoringMethod = method
+}
+
+// SetCommonRandomNumbers sets whether the same set of darts is thrown at every target in each round, as for the
+// grid search.  Each round samples its own set.
+func (e *HalvingSearchEngineInstance) SetCommonRandomNumbers(common bool) {
+	e.commonRandom = common
+}
+
+// SetSeed makes the search repeatable, as for the grid search.  Each round is seeded differently, so that a
+// target scored again in a later round gets new throws.
+func (e *HalvingSearchEngineInstance) SetSeed(seed uint64) {
+	e.seed = seed
+	e.seeded = true
+}
+
+// SetChunkSize sets the number of targets handed to a worker at a time, as for the grid search
+func (e *HalvingSearchEngineInstance) SetChunkSize(size int) {
+	e.chunkSize = size
+}
+
+// Run performs the rounds of the search, and returns the merged results.  The progress callback, if not nil,
+// is called as each target's result arrives, with an estimate of the fraction of the search complete.  If the
+// context is cancelled, or a throw fails, the search stops and the results so far are returned with the error.
+func (e *HalvingSearchEngineInstance) Run(ctx context.Context, progress ProgressCallback) (SimResults, error) {
+	results := NewSimResults()
+	survivors := allTargets(e.supplier)
+	if len(survivors) == 0 {
+		return results, nil
+	}
+	//	For progress reports: the rounds needed to halve down to the minimum survivors, plus a final round
+	expectedRounds := 1.0
+	if len(survivors) > halvingMinSurvivors {
+		expectedRounds += math.Ceil(math.Log2(float64(len(survivors)) / halvingMinSurvivors))
+	}
+
+	throws := e.initialThrows
+	for round := 0; round < halvingMaxRounds; round++ {
+		engine := NewSearchEngine(e.model, NewListTargetSupplier(survivors), throws, e.numWorkers)
+		engine.SetScoringMethod(e.scoringMethod)
+		engine.SetCommonRandomNumbers(e.commonRandom)
+		engine.SetChunkSize(e.chunkSize)
+		if e.seeded {
+			engine.SetSeed(deriveSeed(e.seed, uint64(round)))
+		}
+		roundResults, err := engine.Run(ctx, func(fractionComplete float64, target boardgeo.BoardPosition) {
+			if progress != nil {
+				progress(min(1.0, (float64(round)+fractionComplete)/expectedRounds), target)
+			}
+		})
+		for _, result := range roundResults.GetResultsSlice() {
+			results.MergeTargetResult(TargetResult{
+				Position:  result.Position,
+				Score:     result.Score,
+				Variance:  result.Variance,
+				NumThrows: result.NumThrows,
+			})
+		}
+		if err != nil || e.scoringMethod != ScoringMethod_MonteCarlo {
+			return results, err
+		}
+
+		ranked := rankSurvivors(results, survivors)
+		if bestIsSeparated(ranked) {
+			break
+		}
+		keep := max(halvingMinSurvivors, (len(ranked)+1)/2)
+		survivors = survivors[:0]
+		for i := 0; i < len(ranked) && i < keep; i++ {
+			survivors = append(survivors, ranked[i].Position)
+		}
+		throws = int32(min(math.MaxInt32, 2*int64(throws)))
+	}
+	return results, nil
+}
+
+// rankSurvivors returns the pooled results for the surviving targets, from best to worst
+func rankSurvivors(results SimResults, survivors []boardgeo.BoardPosition) []OneResult {
+	isSurvivor := make(map[boardgeo.BoardPosition]bool, len(survivors))
+	for _, position := range survivors {
+		isSurvivor[position] = true
+	}
+	ranked := make([]OneResult, 0, len(survivors))
+	for _, result := range results.GetResultsSortedByHighScore() {
+		if isSurvivor[result.Position] {
+			ranked = append(ranked, result)
+		}
+	}
+	return ranked
+}
+
+// bestIsSeparated returns true if the best of the ranked results is significantly better than every other named
+// target (e.g. "treble 19") among them.  Nearby targets within one named area are not compared, as they may
+// differ by too little ever to be told apart.
+func bestIsSeparated(ranked []OneResult) bool {
+	namedTargets := MarkTiesWithBest(FilterToOneTargetEach(ranked))
+	for i := 1; i < len(namedTargets); i++ {
+		if namedTargets[i].TiedWithBest {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package target_search
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+)
+
+// MultipleThrowsAtTarget will throw multiple darts at the target position using the given accuracy model,
+// and return the average score
+func MultipleThrowsAtTarget(target boardgeo.BoardPosition, model simulation.AccuracyModel, throws int32) (float64, error) {
+	result, err := ThrowsAtTargetWithVariance(target, model, throws)
+	return result.Score, err
+}
+
+// ThrowsAtTargetWithVariance will throw multiple darts at the target position using the given accuracy model,
+// and return the average score along with the variance of the individual scores and the number of throws, so
+// the precision of the average can be judged
+func ThrowsAtTargetWithVariance(target boardgeo.BoardPosition, model simulation.AccuracyModel, throws int32) (TargetResult, error) {
+	var total float64 = 0.0
+	var totalSquares float64 = 0.0
+	for i := 0; i < int(throws); i++ {
+		hit, err := model.GetThrow(target)
+		if err != nil {
+			return TargetResult{}, err
+		}
+		_, score, _ := boardgeo.DescribeBoardPoint(hit)
+		total += float64(score)
+		totalSquares += float64(score * score)
+	}
+	average := total / float64(throws)
+	variance := 0.0
+	if throws > 1 {
+		variance = max(0, (totalSquares-float64(throws)*average*average)/float64(throws-1))
+	}
+	return TargetResult{Position: target, Score: average, Variance: variance, NumThrows: throws}, nil
+}
+
+// SampleMissVectors throws the given number of darts using the accuracy model and returns where each landed
+// relative to the target, including the model's systematic bias.  Every model scatters its darts the same way
+// whatever the target, so the same misses can be re-used at every target with ThrowsWithMissVectors.
+func SampleMissVectors(model simulation.AccuracyModel, throws int32) ([]simulation.MissVector, error) {
+	centre := boardgeo.CreateBoardPositionFromPolar(0, 0)
+	missVectors := make([]simulation.MissVector, 0, throws)
+	for i := 0; i < int(throws); i++ {
+		hit, err := model.GetThrow(centre)
+		if err != nil {
+			return nil, err
+		}
+		x, y := boardgeo.GetCartesian(hit)
+		missVectors = append(missVectors, simulation.MissVector{X: x, Y: y})
+	}
+	return missVectors, nil
+}
+
+// ThrowsWithMissVectors scores a dart landing at each of the given miss vectors from the target, and returns
+// the average score along with the variance of the individual scores and the number of throws, as for
+// ThrowsAtTargetWithVariance
+func ThrowsWithMissVectors(target boardgeo.BoardPosition, missVectors []simulation.MissVector) TargetResult {
+	targetX, targetY := boardgeo.GetCartesian(target)
+	var total float64 = 0.0
+	var totalSquares float64 = 0.0
+	for _, miss := range missVectors {
+		hit := boardgeo.CreateBoardPositionFromCartesian(targetX+miss.X, targetY+miss.Y)
+		_, score, _ := boardgeo.DescribeBoardPoint(hit)
+		total += float64(score)
+		totalSquares += float64(score * score)
+	}
+	throws := len(missVectors)
+	average := total / float64(throws)
+	variance := 0.0
+	if throws > 1 {
+		variance = max(0, (totalSquares-float64(throws)*average*average)/float64(throws-1))
+	}
+	return TargetResult{Position: target, Score: average, Variance: variance, NumThrows: int32(throws)}
+}
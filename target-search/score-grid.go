@@ -0,0 +1,314 @@
+package target_search
+
+//	A score grid holds the expected score of aiming at every point of the board at once, on a square grid.
+//	Rather than scoring each target separately, the board's score function is drawn onto the grid (like an
+//	image), and blurred by the accuracy model's scatter: the expected score when aiming at a point is the
+//	sum, over every possible miss, of the score where that miss lands times the probability of that miss.
+//	That sum, done for every point together, is a convolution, which the Fast Fourier Transform makes quick.
+//
+//	The scatter "kernel" is calculated directly from the density for Gaussian models, and is otherwise a
+//	histogram of a large number of simulated throws.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	"gonum.org/v1/gonum/dsp/fourier"
+	"math"
+)
+
+// DefaultScoreGridCells is a grid resolution fine enough for searching: 400 cells across the board,
+// each a little under 1mm wide
+const DefaultScoreGridCells = 400
+
+// The score in each cell is the average over this many points along each side of the cell, so that cells
+// straddling the boundary between two areas get a proportionate mix of their scores
+const scoreGridSubsamples = 4
+
+// Number of throws simulated to estimate the kernel of a model that has no formula for its density
+const scoreGridKernelThrows = 1000000
+
+// Misses longer than this can't land on the board from any aim on the board, so the kernel need go no further
+const scoreGridMaxMiss = 2.0
+
+// ScoreGrid is the expected score of aiming at each point of a square grid covering the scoring area
+type ScoreGrid interface {
+	GetCellsAcross() int
+	GetValue(row int, col int) float64
+	GetCellCentre(row int, col int) boardgeo.BoardPosition
+	ScoreAt(target boardgeo.BoardPosition) float64
+	GetBest() (boardgeo.BoardPosition, float64)
+	GetRange() (float64, float64)
+}
+
+// ScoreGridInstance is the data for a score grid.  Row 0 is the top of the board, column 0 the left;
+// the grid runs from -1 to 1 in normalized units in both directions.
+type ScoreGridInstance struct {
+	cellsAcross int
+	cellSize    float64
+	values      []float64 // Row by row
+}
+
+// NewScoreGrid calculates the expected score of aiming at every cell of a grid with the given number of cells
+// across the board, for the given accuracy model
+func NewScoreGrid(model simulation.AccuracyModel, cellsAcross int) (ScoreGrid, error) {
+	cellSize := 2.0 / float64(cellsAcross)
+	scores := rasterizeScores(cellsAcross, cellSize)
+	kernel, kernelRadius, err := scatterKernel(model, cellSize)
+	if err != nil {
+		return nil, err
+	}
+	instance := &ScoreGridInstance{
+		cellsAcross: cellsAcross,
+		cellSize:    cellSize,
+		values:      correlate(scores, cellsAcross, kernel, kernelRadius),
+	}
+	return instance, nil
+}
+
+// GetCellsAcross returns the number of rows, and of columns, in the grid
+func (g *ScoreGridInstance) GetCellsAcross() int {
+	return g.cellsAcross
+}
+
+// GetValue returns the expected score of aiming at the centre of the given cell
+func (g *ScoreGridInstance) GetValue(row int, col int) float64 {
+	return g.values[row*g.cellsAcross+col]
+}
+
+// GetCellCentre returns the board position of the centre of the given cell
+func (g *ScoreGridInstance) GetCellCentre(row int, col int) boardgeo.BoardPosition {
+	x, y := cellCentreCartesian(row, col, g.cellSize)
+	return boardgeo.CreateBoardPositionFromCartesian(x, y)
+}
+
+// ScoreAt returns the expected score of aiming at any point on the board, interpolated between the
+// centres of the surrounding cells
+func (g *ScoreGridInstance) ScoreAt(target boardgeo.BoardPosition) float64 {
+	x, y := boardgeo.GetCartesian(target)
+	//	Fractional column and row, measured between cell centres
+	col := (x+1)/g.cellSize - 0.5
+	row := (1-y)/g.cellSize - 0.5
+	col0 := max(0, min(g.cellsAcross-2, int(math.Floor(col))))
+	row0 := max(0, min(g.cellsAcross-2, int(math.Floor(row))))
+	colFraction := max(0, min(1, col-float64(col0)))
+	rowFraction := max(0, min(1, row-float64(row0)))
+	top := (1-colFraction)*g.GetValue(row0, col0) + colFraction*g.GetValue(row0, col0+1)
+	bottom := (1-colFraction)*g.GetValue(row0+1, col0) + colFraction*g.GetValue(row0+1, col0+1)
+	return (1-rowFraction)*top + rowFraction*bottom
+}
+
+// GetBest returns the centre of the cell with the highest expected score, and that score
+func (g *ScoreGridInstance) GetBest() (boardgeo.BoardPosition, float64) {
+	bestIndex := 0
+	for i, value := range g.values {
+		if value > g.values[bestIndex] {
+			bestIndex = i
+		}
+	}
+	return g.GetCellCentre(bestIndex/g.cellsAcross, bestIndex%g.cellsAcross), g.values[bestIndex]
+}
+
+// GetRange returns the lowest and highest expected scores in the grid
+func (g *ScoreGridInstance) GetRange() (float64, float64) {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, value := range g.values {
+		lowest = math.Min(lowest, value)
+		highest = math.Max(highest, value)
+	}
+	return lowest, highest
+}
+
+// cellCentreCartesian returns the cartesian coordinates of the centre of a cell
+func cellCentreCartesian(row int, col int, cellSize float64) (float64, float64) {
+	return -1 + (float64(col)+0.5)*cellSize, 1 - (float64(row)+0.5)*cellSize
+}
+
+// rasterizeScores draws the board's score function onto the grid, averaging over several points in each cell
+func rasterizeScores(cellsAcross int, cellSize float64) []float64 {
+	scores := make([]float64, cellsAcross*cellsAcross)
+	subStep := cellSize / scoreGridSubsamples
+	for row := 0; row < cellsAcross; row++ {
+		for col := 0; col < cellsAcross; col++ {
+			centreX, centreY := cellCentreCartesian(row, col, cellSize)
+			total := 0
+			for i := 0; i < scoreGridSubsamples; i++ {
+				for j := 0; j < scoreGridSubsamples; j++ {
+					x := centreX - cellSize/2 + (float64(j)+0.5)*subStep
+					y := centreY - cellSize/2 + (float64(i)+0.5)*subStep
+					_, score, _ := boardgeo.DescribeBoardPoint(boardgeo.CreateBoardPositionFromCartesian(x, y))
+					total += score
+				}
+			}
+			scores[row*cellsAcross+col] = float64(total) / (scoreGridSubsamples * scoreGridSubsamples)
+		}
+	}
+	return scores
+}
+
+// scatterKernel returns the probability of each possible miss, as a square grid of cells the same size as the
+// score grid, centred on a perfect throw.  Row offsets increase downwards, as in the score grid.  The radius
+// returned is the number of cells from the centre to the edge of the kernel.
+func scatterKernel(model simulation.AccuracyModel, cellSize float64) ([]float64, int, error) {
+	if gaussianModel, isGaussian := model.(simulation.GaussianAccuracyModel); isGaussian {
+		kernel, radius := gaussianKernel(gaussianModel, cellSize)
+		return kernel, radius, nil
+	}
+	return sampledKernel(model, cellSize)
+}
+
+// gaussianKernel calculates the kernel of a Gaussian model from its density, averaged over several points in
+// each cell, out to the clipping distance of the widest component
+func gaussianKernel(model simulation.GaussianAccuracyModel, cellSize float64) ([]float64, int) {
+	offsetX, offsetY := model.GetMeanOffset()
+	components := model.GetGaussianComponents()
+	reach := math.Hypot(offsetX, offsetY)
+	for _, component := range components {
+		reach = math.Max(reach, math.Hypot(offsetX, offsetY)+analyticClipSigmas*math.Sqrt(math.Max(component.VarianceX, component.VarianceY)))
+	}
+	radius := int(math.Ceil(math.Min(reach, scoreGridMaxMiss) / cellSize))
+	size := 2*radius + 1
+	kernel := make([]float64, size*size)
+
+	subStep := cellSize / scoreGridSubsamples
+	for _, component := range components {
+		if component.Weight <= 0 {
+			continue
+		}
+		determinant := component.VarianceX*component.VarianceY - component.Covariance*component.Covariance
+		if determinant <= 0 {
+			//	No spread: every throw lands on the offset
+			col := radius + int(math.Round(offsetX/cellSize))
+			row := radius - int(math.Round(offsetY/cellSize))
+			if row >= 0 && row < size && col >= 0 && col < size {
+				kernel[row*size+col] += component.Weight
+			}
+			continue
+		}
+		density := newGaussianDensity(offsetX, offsetY, component)
+		for row := 0; row < size; row++ {
+			for col := 0; col < size; col++ {
+				centreX := float64(col-radius) * cellSize
+				centreY := float64(radius-row) * cellSize
+				total := 0.0
+				for i := 0; i < scoreGridSubsamples; i++ {
+					for j := 0; j < scoreGridSubsamples; j++ {
+						total += density.at(centreX-cellSize/2+(float64(j)+0.5)*subStep, centreY-cellSize/2+(float64(i)+0.5)*subStep)
+					}
+				}
+				kernel[row*size+col] += component.Weight * total * subStep * subStep
+			}
+		}
+	}
+	return kernel, radius
+}
+
+// sampledKernel estimates the kernel of any model as the histogram of a large number of throws at the centre
+// of the board
+func sampledKernel(model simulation.AccuracyModel, cellSize float64) ([]float64, int, error) {
+	radius := int(math.Ceil(scoreGridMaxMiss / cellSize))
+	size := 2*radius + 1
+	kernel := make([]float64, size*size)
+	centre := boardgeo.CreateBoardPositionFromPolar(0, 0)
+	for i := 0; i < scoreGridKernelThrows; i++ {
+		hit, err := model.GetThrow(centre)
+		if err != nil {
+			return nil, 0, err
+		}
+		x, y := boardgeo.GetCartesian(hit)
+		col := radius + int(math.Round(x/cellSize))
+		row := radius - int(math.Round(y/cellSize))
+		if row >= 0 && row < size && col >= 0 && col < size {
+			kernel[row*size+col] += 1.0 / scoreGridKernelThrows
+		}
+	}
+	return kernel, radius, nil
+}
+
+// correlate returns, for every cell of the score grid, the sum over the kernel of the score where each miss
+// lands times the probability of that miss
+//
+//	Both grids are copied into a larger square, padded with zeros so that misses running off one side of the
+//	board don't wrap around to the other, transformed, multiplied (the kernel conjugated, which turns the
+//	convolution into the correlation we need), and transformed back
+func correlate(scores []float64, cellsAcross int, kernel []float64, kernelRadius int) []float64 {
+	paddedSize := fftFriendlySize(cellsAcross + kernelRadius)
+	paddedScores := make([]complex128, paddedSize*paddedSize)
+	for row := 0; row < cellsAcross; row++ {
+		for col := 0; col < cellsAcross; col++ {
+			paddedScores[row*paddedSize+col] = complex(scores[row*cellsAcross+col], 0)
+		}
+	}
+	//	The kernel is centred on index 0, with negative offsets wrapped round to the far end
+	kernelSize := 2*kernelRadius + 1
+	paddedKernel := make([]complex128, paddedSize*paddedSize)
+	for row := 0; row < kernelSize; row++ {
+		paddedRow := (row - kernelRadius + paddedSize) % paddedSize
+		for col := 0; col < kernelSize; col++ {
+			paddedCol := (col - kernelRadius + paddedSize) % paddedSize
+			paddedKernel[paddedRow*paddedSize+paddedCol] = complex(kernel[row*kernelSize+col], 0)
+		}
+	}
+
+	fft := fourier.NewCmplxFFT(paddedSize)
+	transform2D(fft, paddedScores, paddedSize, false)
+	transform2D(fft, paddedKernel, paddedSize, false)
+	for i := range paddedScores {
+		kernelCoefficient := paddedKernel[i]
+		paddedScores[i] *= complex(real(kernelCoefficient), -imag(kernelCoefficient))
+	}
+	transform2D(fft, paddedScores, paddedSize, true)
+
+	//	The transforms are unnormalized, so the round trip has multiplied everything by the number of points
+	normalization := float64(paddedSize * paddedSize)
+	values := make([]float64, cellsAcross*cellsAcross)
+	for row := 0; row < cellsAcross; row++ {
+		for col := 0; col < cellsAcross; col++ {
+			//	Rounding in the transforms can leave tiny negative values where the score should be zero
+			values[row*cellsAcross+col] = math.Max(0, real(paddedScores[row*paddedSize+col])/normalization)
+		}
+	}
+	return values
+}
+
+// transform2D applies the Fourier transform, or its inverse, in place to a square grid: to every row, then
+// to every column
+func transform2D(fft *fourier.CmplxFFT, data []complex128, size int, inverse bool) {
+	line := make([]complex128, size)
+	apply := func() {
+		if inverse {
+			fft.Sequence(line, line)
+		} else {
+			fft.Coefficients(line, line)
+		}
+	}
+	for row := 0; row < size; row++ {
+		copy(line, data[row*size:(row+1)*size])
+		apply()
+		copy(data[row*size:(row+1)*size], line)
+	}
+	for col := 0; col < size; col++ {
+		for row := 0; row < size; row++ {
+			line[row] = data[row*size+col]
+		}
+		apply()
+		for row := 0; row < size; row++ {
+			data[row*size+col] = line[row]
+		}
+	}
+}
+
+// fftFriendlySize returns the smallest size at least n with no prime factors other than 2, 3, and 5,
+// for which the Fast Fourier Transform is fastest
+func fftFriendlySize(n int) int {
+	for size := n; ; size++ {
+		remainder := size
+		for _, factor := range []int{2, 3, 5} {
+			for remainder%factor == 0 {
+				remainder /= factor
+			}
+		}
+		if remainder == 1 {
+			return size
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package target_search
+
+//	The search engine runs the search for the best target: it takes targets from a TargetSupplier,
+//	throws a large number of darts at each using an AccuracyModel (or, for Gaussian models, calculates
+//	the expected score exactly), and collects the average scores in a SimResults object.  The work is
+//	spread across a pool of worker threads, each throwing with its own copy of the model, so that the
+//	workers never wait on each other's random numbers.  The targets are handed out, and the results
+//	returned, in blocks, so that passing work between the threads costs little compared with the work.
+//	The engine has no knowledge of the user interface, so the GUI, the command-line tool, and any
+//	other code can all drive the same search.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	"context"
+	"errors"
+	"math"
+	"sync"
+)
+
+// ProgressCallback is called by the search engine each time a block of targets has been scored, with the
+// fraction (0 to 1) of the forecast number of targets that are now complete, and the last target of the block.
+// It is called from the engine's own thread, never concurrently with itself.
+type ProgressCallback func(fractionComplete float64, target boardgeo.BoardPosition)
+
+// ScoringMethod selects how the engine works out the average score at each target
+type ScoringMethod int
+
+const (
+	ScoringMethod_MonteCarlo ScoringMethod = iota // Average the scores of many simulated throws
+	ScoringMethod_Analytic                        // Calculate the expected score exactly (Gaussian models only)
+	ScoringMethod_ScoreGrid                       // Look up each target in a ScoreGrid of the whole board
+)
+
+// ErrModelNotGaussian is returned when analytic scoring is requested with a model whose distribution isn't Gaussian
+var ErrModelNotGaussian = errors.New("analytic scoring needs a Gaussian accuracy model")
+
+// SearchEngine is a runnable search for the best target
+type SearchEngine interface {
+	Run(ctx context.Context, progress ProgressCallback) (SimResults, error)
+	SetScoringMethod(method ScoringMethod)
+	SetCommonRandomNumbers(common bool)
+	SetSeed(seed uint64)
+	SetChunkSize(size int)
+}
+
+// SearchEngineInstance is the data for a multi-threaded search
+type SearchEngineInstance struct {
+	model           simulation.AccuracyModel
+	supplier        TargetSupplier
+	throwsPerTarget int32
+	numWorkers      int
+	scoringMethod   ScoringMethod
+	scoreGrid       ScoreGrid // Calculated at the start of the run, for the score grid method
+	commonRandom    bool
+	missVectors     []simulation.MissVector // Sampled at the start of the run, for common random numbers
+	seed            uint64
+	seeded          bool
+	chunkSize       int // Targets in each block handed to a worker, or 0 to choose automatically
+}
+
+// With an automatic chunk size, the targets are divided into about this many blocks for each worker, so that
+// the workers finish at nearly the same time however the work per target varies
+const autoChunksPerWorker = 8
+
+// Largest automatic chunk size; beyond this the saving in coordination is negligible
+const maxAutoChunkSize = 256
+
+// Separate random number streams derived from the engine's seed, apart from those of the targets
+const (
+	seedStreamScoreGrid = iota + 1
+	seedStreamMissVectors
+)
+
+// NewSearchEngine creates a search engine that throws throwsPerTarget darts, using the given accuracy
+// model, at every target from the supplier, spread across numWorkers threads
+func NewSearchEngine(model simulation.AccuracyModel,
+	supplier TargetSupplier,
+	throwsPerTarget int32,
+	numWorkers int) SearchEngine {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	instance := &SearchEngineInstance{
+		model:           model,
+		supplier:        supplier,
+		throwsPerTarget: throwsPerTarget,
+		numWorkers:      numWorkers,
+		scoringMethod:   ScoringMethod_MonteCarlo,
+	}
+	return instance
+}
+
+// SetScoringMethod sets how the average score at each target is found.  Monte-Carlo, the default, works with
+// any model; analytic scoring needs a simulation.GaussianAccuracyModel, and ignores the number of throws.
+// The score grid method works with any model, and also ignores the number of throws.
+func (e *SearchEngineInstance) SetScoringMethod(method ScoringMethod) {
+	e.scoringMethod = method
+}
+
+// SetCommonRandomNumbers sets whether Monte-Carlo scoring throws the same set of darts at every target: one set
+// of misses is sampled at the start of the run and re-used at each target, rather than throwing fresh darts.
+// The luck of the throws is then the same everywhere, so it largely cancels out when nearby targets are
+// compared, and the ranking is much more stable from run to run.  (The standard errors are still those of each
+// target on its own, so the tests for ties with the best are cautious.)
+func (e *SearchEngineInstance) SetCommonRandomNumbers(common bool) {
+	e.commonRandom = common
+}
+
+// SetSeed makes the search repeatable: every random number used is derived from the seed, so the same search
+// with the same seed gives exactly the same results.  Which worker scores which target depends on the timing of
+// the threads, so rather than each worker's copy of the model running on through its own stream of random
+// numbers, the copy is re-seeded for every target from the seed and the target's position.
+func (e *SearchEngineInstance) SetSeed(seed uint64) {
+	e.seed = seed
+	e.seeded = true
+}
+
+// SetChunkSize sets the number of targets handed to a worker at a time, and returned as one block of results.
+// Zero, the default, chooses a size from the number of targets and workers.
+func (e *SearchEngineInstance) SetChunkSize(size int) {
+	e.chunkSize = max(0, size)
+}
+
+// Run performs the search and returns the results.  The progress callback, if not nil, is called
+// as each block of results arrives.  If the context is cancelled the search stops and the partial
+// results are returned along with the context's error.  If a throw fails, the search stops and
+// that error is returned.
+func (e *SearchEngineInstance) Run(ctx context.Context, progress ProgressCallback) (SimResults, error) {
+	results := NewSimResults()
+	if _, isGaussian := e.model.(simulation.GaussianAccuracyModel); e.scoringMethod == ScoringMethod_Analytic && !isGaussian {
+		return results, ErrModelNotGaussian
+	}
+	if e.scoringMethod == ScoringMethod_ScoreGrid {
+		scoreGrid, err := NewScoreGrid(e.streamModel(seedStreamScoreGrid), DefaultScoreGridCells)
+		if err != nil {
+			return results, err
+		}
+		e.scoreGrid = scoreGrid
+	}
+	e.missVectors = nil
+	if e.commonRandom && e.scoringMethod == ScoringMethod_MonteCarlo {
+		missVectors, err := SampleMissVectors(e.streamModel(seedStreamMissVectors), e.throwsPerTarget)
+		if err != nil {
+			return results, err
+		}
+		e.missVectors = missVectors
+	}
+
+	//	Workers stop when the caller cancels, or when one of them reports an error
+	workContext, cancelWork := context.WithCancel(ctx)
+	defer cancelWork()
+
+	// Channels to send blocks of targets and receive blocks of results
+	forecastNumTargets := e.supplier.ForecastNumTargets()
+	chunkSize := e.chunkSizeFor(forecastNumTargets)
+	channelCapacity := int(forecastNumTargets)/chunkSize + 1
+	targetsChannel := make(chan []boardgeo.BoardPosition, channelCapacity)
+	resultsChannel := make(chan []TargetResult, channelCapacity)
+	errorsChannel := make(chan error, e.numWorkers)
+
+	//	Start worker threads
+	var wg sync.WaitGroup
+	for i := 0; i < e.numWorkers; i++ {
+		wg.Add(1)
+		go e.workerThread(workContext, cancelWork, targetsChannel, resultsChannel, errorsChannel, &wg)
+	}
+
+	// Fill the targets channel, a block at a time
+	go func() {
+		defer close(targetsChannel)
+		for e.supplier.HasNext() {
+			block := make([]boardgeo.BoardPosition, 0, chunkSize)
+			for len(block) < chunkSize && e.supplier.HasNext() {
+				block = append(block, e.supplier.NextTarget())
+			}
+			select {
+			case <-workContext.Done():
+				return
+			case targetsChannel <- block:
+			}
+		}
+	}()
+
+	// Close the results channel once all the workers have finished
+	go func() {
+		wg.Wait()
+		close(resultsChannel)
+	}()
+
+	// Read the results as they come in
+	numResults := 0
+	totalResultsDenominator := float64(forecastNumTargets)
+	for block := range resultsChannel {
+		for _, result := range block {
+			results.AddTargetResult(result)
+		}
+		numResults += len(block)
+		if progress != nil && len(block) > 0 {
+			progress(min(1.0, float64(numResults)/totalResultsDenominator), block[len(block)-1].Position)
+		}
+	}
+
+	if ctx.Err() != nil {
+		return results, ctx.Err()
+	}
+	select {
+	case err := <-errorsChannel:
+		return results, err
+	default:
+		return results, nil
+	}
+}
+
+// chunkSizeFor returns the number of targets in each block handed to a worker, for the forecast number of targets
+func (e *SearchEngineInstance) chunkSizeFor(forecastNumTargets int32) int {
+	if e.chunkSize > 0 {
+		return e.chunkSize
+	}
+	return max(1, min(maxAutoChunkSize, int(forecastNumTargets)/(e.numWorkers*autoChunksPerWorker)))
+}
+
+// streamModel returns the model to use for one of the engine's own random number streams: the model itself if the
+// search isn't seeded, otherwise a copy seeded for that stream
+func (e *SearchEngineInstance) streamModel(stream uint64) simulation.AccuracyModel {
+	if !e.seeded {
+		return e.model
+	}
+	model := e.model.Clone()
+	model.SetSeed(deriveSeed(e.seed, stream))
+	return model
+}
+
+// scoreTarget returns the result for one target, by the engine's scoring method, throwing with the given model
+func (e *SearchEngineInstance) scoreTarget(target boardgeo.BoardPosition, model simulation.AccuracyModel) (TargetResult, error) {
+	switch e.scoringMethod {
+	case ScoringMethod_Analytic:
+		return TargetResult{Position: target, Score: ExpectedScore(target, e.model.(simulation.GaussianAccuracyModel))}, nil
+	case ScoringMethod_ScoreGrid:
+		return TargetResult{Position: target, Score: e.scoreGrid.ScoreAt(target)}, nil
+	}
+	if e.missVectors != nil {
+		return ThrowsWithMissVectors(target, e.missVectors), nil
+	}
+	if e.seeded {
+		model.SetSeed(deriveSeed(e.seed, math.Float64bits(target.Radius), math.Float64bits(target.Angle)))
+	}
+	return ThrowsAtTargetWithVariance(target, model, e.throwsPerTarget)
+}
+
+// workerThread takes blocks of targets from the targets channel, throws at each target, and sends the block
+// of average scores to the results channel, until the targets run out or the work is cancelled.
+// A failed throw is reported on the errors channel and cancels the work of all the other workers.
+// Each worker throws with its own copy of the model, which has its own random number source.
+func (e *SearchEngineInstance) workerThread(
+	ctx context.Context,
+	cancelWork context.CancelFunc,
+	targetsChannel chan []boardgeo.BoardPosition,
+	resultsChannel chan []TargetResult,
+	errorsChannel chan error,
+	wg *sync.WaitGroup) {
+	defer wg.Done()
+	model := e.model.Clone()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case targets, ok := <-targetsChannel:
+			if !ok {
+				return
+			}
+			block := make([]TargetResult, 0, len(targets))
+			for _, target := range targets {
+				if ctx.Err() != nil {
+					return
+				}
+				result, err := e.scoreTarget(target, model)
+				if err != nil {
+					errorsChannel <- err
+					cancelWork()
+					return
+				}
+				block = append(block, result)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultsChannel <- block:
+			}
+		}
+	}
+}
+
+// deriveSeed mixes a seed with one or more values to give a new seed, so that separate streams of random numbers
+// can be derived from one seed.  Each value is folded in with the SplitMix64 mixing function, which spreads every
+// bit of its input over the whole output, so even similar values give unrelated seeds.
+func deriveSeed(seed uint64, values ...uint64) uint64 {
+	mixed := seed
+	for _, value := range values {
+		mixed ^= value
+		mixed += 0x9e3779b97f4a7c15
+		mixed = (mixed ^ (mixed >> 30)) * 0xbf58476d1ce4e5b9
+		mixed = (mixed ^ (mixed >> 27)) * 0x94d049bb133111eb
+		mixed ^= mixed >> 31
+	}
+	return mixed
+}
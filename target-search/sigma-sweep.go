@@ -0,0 +1,152 @@
+package target_search
+
+//	A sigma sweep answers the question that motivates this whole program: at what accuracy should a player
+//	switch targets - for example from treble 20, best for an accurate player, to treble 19, which has kinder
+//	neighbours?  The best target is searched for at each of a range of standard deviations and, wherever the
+//	best segment differs between neighbouring standard deviations, the standard deviation at which it changes
+//	is found by bisection.
+//	The searches must compare near-equal targets precisely, so each is an adaptive search with exact scores
+//	(or, for a model with no formula for its distribution, the whole-board score grid), which also makes each
+//	one quick.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+	"context"
+	"math"
+)
+
+// Crossover standard deviations are found to within this
+const sigmaCrossoverTolerance = 0.001
+
+// SigmaSweepPoint is the best target found at one standard deviation
+type SigmaSweepPoint struct {
+	StdDev  float64
+	Best    OneResult
+	Segment int // Segment of the best target: 1 to 20, 25 for the bull, or 0 if off the board
+}
+
+// SigmaCrossover is a standard deviation at which the best segment changes
+type SigmaCrossover struct {
+	StdDev      float64
+	FromSegment int // Best segment for standard deviations just below the crossover
+	ToSegment   int // Best segment just above it
+}
+
+// SweepStdDevs returns the standard deviations from one value to another in the given steps, including both ends.
+// The steps are rounded to remove floating-point noise, so they can be shown as they are.
+func SweepStdDevs(from float64, to float64, step float64) []float64 {
+	numSteps := int(math.Ceil((to-from)/step - 1e-9))
+	stdDevs := make([]float64, 0, numSteps+1)
+	for i := 0; i < numSteps; i++ {
+		stdDevs = append(stdDevs, AddWithoutNoise(from, float64(i)*step))
+	}
+	return append(stdDevs, to)
+}
+
+// SigmaSweep searches for the best target, with the given model set to each of the given standard deviations
+// (which should be in increasing order), and returns the best at each along with the crossovers between them.
+// The model itself is not changed.  Each search is spread across numWorkers threads.  If the seed isn't 0, every
+// search is seeded from it, so the same sweep with the same seed gives exactly the same results.
+func SigmaSweep(ctx context.Context,
+	model simulation.AccuracyModel,
+	stdDevs []float64,
+	numWorkers int,
+	seed uint64) ([]SigmaSweepPoint, []SigmaCrossover, error) {
+	points := make([]SigmaSweepPoint, 0, len(stdDevs))
+	for _, stdDev := range stdDevs {
+		point, err := bestAtStdDev(ctx, model, stdDev, numWorkers, seed)
+		if err != nil {
+			return points, nil, err
+		}
+		points = append(points, point)
+	}
+
+	crossovers := make([]SigmaCrossover, 0)
+	for i := 1; i < len(points); i++ {
+		if points[i].Segment == points[i-1].Segment {
+			continue
+		}
+		crossover, err := findCrossover(ctx, model, points[i-1], points[i], numWorkers, seed)
+		if err != nil {
+			return points, crossovers, err
+		}
+		crossovers = append(crossovers, crossover)
+	}
+	return points, crossovers, nil
+}
+
+// findCrossover narrows the interval between two sweep points with different best segments, by bisection,
+// until it is smaller than the tolerance.  If the best segment changes more than once in the interval, one
+// of the changes is found.
+func findCrossover(ctx context.Context,
+	model simulation.AccuracyModel,
+	below SigmaSweepPoint,
+	above SigmaSweepPoint,
+	numWorkers int,
+	seed uint64) (SigmaCrossover, error) {
+	for above.StdDev-below.StdDev > sigmaCrossoverTolerance {
+		middle, err := bestAtStdDev(ctx, model, (below.StdDev+above.StdDev)/2, numWorkers, seed)
+		if err != nil {
+			return SigmaCrossover{}, err
+		}
+		if middle.Segment == below.Segment {
+			below = middle
+		} else {
+			above = middle
+		}
+	}
+	return SigmaCrossover{
+		StdDev:      (below.StdDev + above.StdDev) / 2,
+		FromSegment: below.Segment,
+		ToSegment:   above.Segment,
+	}, nil
+}
+
+// bestAtStdDev searches for the best target with a copy of the model set to the given standard deviation
+func bestAtStdDev(ctx context.Context, model simulation.AccuracyModel, stdDev float64, numWorkers int, seed uint64) (SigmaSweepPoint, error) {
+	sweepModel := modelAtStdDev(model, stdDev, seed)
+	engine := NewAdaptiveSearchEngine(sweepModel, DefaultAdaptiveThrowBudget, numWorkers)
+	if seed != 0 {
+		engine.SetSeed(deriveSeed(seed, math.Float64bits(stdDev)))
+	}
+	if _, isGaussian := sweepModel.(simulation.GaussianAccuracyModel); isGaussian {
+		engine.SetScoringMethod(ScoringMethod_Analytic)
+	} else {
+		engine.SetScoringMethod(ScoringMethod_ScoreGrid)
+	}
+	results, err := engine.Run(ctx, nil)
+	if err != nil {
+		return SigmaSweepPoint{}, err
+	}
+	sorted := results.GetResultsSortedByHighScore()
+	if len(sorted) == 0 {
+		return SigmaSweepPoint{StdDev: stdDev}, nil
+	}
+	return SigmaSweepPoint{
+		StdDev:  stdDev,
+		Best:    sorted[0],
+		Segment: SegmentOf(sorted[0].Position),
+	}, nil
+}
+
+// modelAtStdDev returns a copy of the model set to the given standard deviation and, if the seed isn't 0, seeded
+// from it and the standard deviation, so each standard deviation of a seeded sweep has its own repeatable throws
+func modelAtStdDev(model simulation.AccuracyModel, stdDev float64, seed uint64) simulation.AccuracyModel {
+	sweepModel := model.Clone()
+	sweepModel.SetStandardDeviation(stdDev)
+	if seed != 0 {
+		sweepModel.SetSeed(deriveSeed(seed, math.Float64bits(stdDev)))
+	}
+	return sweepModel
+}
+
+// SegmentOf returns the segment containing a board position: 1 to 20, 25 for either bull, or 0 if the
+// position is off the scoring area
+func SegmentOf(position boardgeo.BoardPosition) int {
+	x, y := boardgeo.GetCartesian(position)
+	if i, found := regionIndexContaining(x, y); found {
+		return scoringRegions[i].SegmentValue
+	}
+	return 0
+}
@@ -0,0 +1,25 @@
+package target_search
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestSweepStdDevs checks that the standard deviations of a sweep are free of floating-point noise and end
+// exactly on the upper limit, even when the steps don't reach it evenly
+func TestSweepStdDevs(t *testing.T) {
+	tests := []struct {
+		from, to, step float64
+		want           []float64
+	}{
+		{0.1, 0.15, 0.01, []float64{0.1, 0.11, 0.12, 0.13, 0.14, 0.15}},
+		{0.02, 0.3, 0.07, []float64{0.02, 0.09, 0.16, 0.23, 0.3}},
+		{0.05, 0.12, 0.03, []float64{0.05, 0.08, 0.11, 0.12}},
+	}
+	for _, test := range tests {
+		got := SweepStdDevs(test.from, test.to, test.step)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("SweepStdDevs(%g, %g, %g) = %v, want %v", test.from, test.to, test.step, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package target_search
+
+//	With a Monte-Carlo search, each average score is only an estimate, and the estimates for the top few
+//	targets are often closer together than their sampling error.  Here we test whether each target's average
+//	really differs from the best one's, or whether the difference could easily be chance.
+
+import (
+	"gonum.org/v1/gonum/stat/distuv"
+	"math"
+)
+
+// Targets whose difference from the best is less significant than this are reported as tied with it
+const tieSignificanceLevel = 0.05
+
+// MarkTiesWithBest sets TiedWithBest on each result whose average score is not significantly different from the
+// first (best) result's, by Welch's t-test, which allows the two targets' scores to have different variances.
+// Exact scores, with no sampling error, are only tied if they are equal.  The best result is tied with itself.
+// The results are expected in order from best to worst, as from GetResultsSortedByHighScore.
+func MarkTiesWithBest(results []OneResult) []OneResult {
+	if len(results) == 0 {
+		return results
+	}
+	best := results[0]
+	for i := range results {
+		results[i].TiedWithBest = welchPValue(best, results[i]) >= tieSignificanceLevel
+	}
+	return results
+}
+
+// welchPValue returns the two-sided probability of seeing at least the difference between the average scores
+// of two results if their true averages were equal
+func welchPValue(a OneResult, b OneResult) float64 {
+	varianceA := a.StdError() * a.StdError()
+	varianceB := b.StdError() * b.StdError()
+	difference := math.Abs(a.Score - b.Score)
+	if varianceA+varianceB == 0 {
+		if difference == 0 {
+			return 1
+		}
+		return 0
+	}
+	t := difference / math.Sqrt(varianceA+varianceB)
+
+	//	The Welch-Satterthwaite approximation to the degrees of freedom.  An exact result contributes no
+	//	variance, and so nothing to the denominator.
+	denominator := 0.0
+	if a.NumThrows > 1 {
+		denominator += varianceA * varianceA / float64(a.NumThrows-1)
+	}
+	if b.NumThrows > 1 {
+		denominator += varianceB * varianceB / float64(b.NumThrows-1)
+	}
+	degreesOfFreedom := math.Inf(1)
+	if denominator > 0 {
+		degreesOfFreedom = (varianceA + varianceB) * (varianceA + varianceB) / denominator
+	}
+	if math.IsInf(degreesOfFreedom, 1) {
+		unitNormal := distuv.Normal{Mu: 0, Sigma: 1}
+		return 2 * unitNormal.Survival(t)
+	}
+	studentsT := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: degreesOfFreedom}
+	return 2 * studentsT.Survival(t)
+}
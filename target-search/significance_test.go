@@ -0,0 +1,54 @@
+package target_search
+
+import (
+	"math"
+	"testing"
+)
+
+// TestWelchPValue checks the p-value against the critical values in tables of Student's t and the normal
+// distribution.  With equal variances and numbers of throws n, the degrees of freedom are 2(n-1); with a single
+// throw each, none are estimated from the data, so the normal distribution applies.
+func TestWelchPValue(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b OneResult
+		want float64
+	}{
+		{"t 10 df at 5%", OneResult{Score: 10, Variance: 6, NumThrows: 6},
+			OneResult{Score: 10 - 2.228138852*math.Sqrt2, Variance: 6, NumThrows: 6}, 0.05},
+		{"t 4 df at 5%", OneResult{Score: 20, Variance: 3, NumThrows: 3},
+			OneResult{Score: 20 - 2.776445105*math.Sqrt2, Variance: 3, NumThrows: 3}, 0.05},
+		{"t 4 df at 1%", OneResult{Score: 20, Variance: 3, NumThrows: 3},
+			OneResult{Score: 20 + 4.604094871*math.Sqrt2, Variance: 3, NumThrows: 3}, 0.01},
+		{"normal at 5%", OneResult{Score: 5, Variance: 0.5, NumThrows: 1},
+			OneResult{Score: 5 - 1.959963985, Variance: 0.5, NumThrows: 1}, 0.05},
+		{"equal averages", OneResult{Score: 7, Variance: 2, NumThrows: 50},
+			OneResult{Score: 7, Variance: 9, NumThrows: 20}, 1},
+		{"exact and equal", OneResult{Score: 40}, OneResult{Score: 40}, 1},
+		{"exact and different", OneResult{Score: 40}, OneResult{Score: 39.9}, 0},
+	}
+	for _, test := range tests {
+		if got := welchPValue(test.a, test.b); math.Abs(got-test.want) > 1e-6 {
+			t.Errorf("%s: got p-value %.8f, want %g", test.name, got, test.want)
+		}
+		if got := welchPValue(test.b, test.a); math.Abs(got-test.want) > 1e-6 {
+			t.Errorf("%s, reversed: got p-value %.8f, want %g", test.name, got, test.want)
+		}
+	}
+}
+
+// TestMarkTiesWithBest checks that results within sampling error of the best are tied with it, and others aren't
+func TestMarkTiesWithBest(t *testing.T) {
+	results := []OneResult{
+		{Score: 45, Variance: 400, NumThrows: 1000},
+		{Score: 44.5, Variance: 400, NumThrows: 1000},
+		{Score: 40, Variance: 400, NumThrows: 1000},
+		{Score: 44.9},
+	}
+	want := []bool{true, true, false, true}
+	for i, result := range MarkTiesWithBest(results) {
+		if result.TiedWithBest != want[i] {
+			t.Errorf("result %d (score %g): got tied %v, want %v", i, result.Score, result.TiedWithBest, want[i])
+		}
+	}
+}
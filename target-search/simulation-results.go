@@ -2,19 +2,25 @@ package target_search
 
 import (
 	boardgeo "DStratMC/board-geometry"
+	"math"
 	"slices"
 	"sort"
 )
 
 // SimResults stores the results of a simulation run - each target position tried, and its average score
 
+// TargetResult is the result of scoring one target.  Variance is the variance of the score of a single throw,
+// and NumThrows the number of throws averaged; NumThrows is zero if the score was calculated exactly.
 type TargetResult struct {
-	Position boardgeo.BoardPosition
-	Score    float64
+	Position  boardgeo.BoardPosition
+	Score     float64
+	Variance  float64
+	NumThrows int32
 }
 
 type SimResults interface {
 	AddTargetResult(result TargetResult)
+	MergeTargetResult(result TargetResult)
 	GetResultsSortedByHighScore() []OneResult
 	GetResultsSlice() []OneResult
 	GetNumResults() uint32
@@ -22,29 +28,48 @@ type SimResults interface {
 
 // SimResultsInstance is data for the instance of the SimResults object
 type SimResultsInstance struct {
-	resultsMap map[boardgeo.BoardPosition]float64
+	resultsMap map[boardgeo.BoardPosition]TargetResult
 }
 
 // NewSimResults creates a new SimResults object
 func NewSimResults() SimResults {
 	results := &SimResultsInstance{
-		resultsMap: make(map[boardgeo.BoardPosition]float64, 4000),
+		resultsMap: make(map[boardgeo.BoardPosition]TargetResult, 4000),
 	}
 	//fmt.Println("NewSimResults  returns", results)
 	return results
 }
 
-// OneResult is a single result - a position tried and the average score at that position
+// OneResult is a single result - a position tried and the average score at that position, with the
+// variance of a single throw's score and the number of throws averaged (zero if the score is exact).
+// TiedWithBest is set by MarkTiesWithBest.
 type OneResult struct {
-	Position boardgeo.BoardPosition
-	Score    float64
+	Position     boardgeo.BoardPosition
+	Score        float64
+	Variance     float64
+	NumThrows    int32
+	TiedWithBest bool
+}
+
+// StdError returns the standard error of the average score: the typical difference between the average
+// found and the true average, due to the random sampling of throws.  Exact scores have no error.
+func (r OneResult) StdError() float64 {
+	if r.NumThrows == 0 {
+		return 0
+	}
+	return math.Sqrt(r.Variance / float64(r.NumThrows))
 }
 
 // GetResultsSlice returns the results as a slice of OneResult objects, in no particular order
 func (s SimResultsInstance) GetResultsSlice() []OneResult {
 	slice := make([]OneResult, 0, len(s.resultsMap))
-	for pos, score := range s.resultsMap {
-		slice = append(slice, OneResult{Position: pos, Score: score})
+	for pos, result := range s.resultsMap {
+		slice = append(slice, OneResult{
+			Position:  pos,
+			Score:     result.Score,
+			Variance:  result.Variance,
+			NumThrows: result.NumThrows,
+		})
 	}
 	return slice
 }
@@ -53,20 +78,57 @@ func (s SimResultsInstance) GetNumResults() uint32 {
 	return uint32(len(s.resultsMap))
 }
 
-// GetResultsSortedByHighScore returns the positions sorted from the highest average score to the lowest
+// GetResultsSortedByHighScore returns the positions sorted from the highest average score to the lowest.
+// Equal scores are ordered by position, so the order is always the same for the same results.
 func (s SimResultsInstance) GetResultsSortedByHighScore() []OneResult {
 	// Convert map to slice
 	slice := s.GetResultsSlice()
 	// Sort the slice by descending order of score
 	sort.Slice(slice, func(i, j int) bool {
-		return slice[i].Score > slice[j].Score
+		if slice[i].Score != slice[j].Score {
+			return slice[i].Score > slice[j].Score
+		}
+		if slice[i].Position.Radius != slice[j].Position.Radius {
+			return slice[i].Position.Radius < slice[j].Position.Radius
+		}
+		return slice[i].Position.Angle < slice[j].Position.Angle
 	})
 	return slice
 }
 
 // AddTargetResult adds a target position and its average score to the results list
 func (s SimResultsInstance) AddTargetResult(result TargetResult) {
-	s.resultsMap[result.Position] = result.Score
+	s.resultsMap[result.Position] = result
+}
+
+// MergeTargetResult adds a target's result to the results list.  If the same target has already been scored
+// by throws, the two sets of throws are pooled, so the average is over all of them; otherwise the new result
+// replaces any existing one.
+func (s SimResultsInstance) MergeTargetResult(result TargetResult) {
+	existing, found := s.resultsMap[result.Position]
+	if found && existing.NumThrows > 0 && result.NumThrows > 0 {
+		result = CombineTargetResults(existing, result)
+	}
+	s.resultsMap[result.Position] = result
+}
+
+// CombineTargetResults returns the result of pooling the throws of two results for the same target: the
+// average and variance are those that would have been found from all the throws together.
+func CombineTargetResults(a TargetResult, b TargetResult) TargetResult {
+	numThrows := a.NumThrows + b.NumThrows
+	if numThrows == 0 {
+		return b
+	}
+	countA, countB, count := float64(a.NumThrows), float64(b.NumThrows), float64(numThrows)
+	average := (countA*a.Score + countB*b.Score) / count
+	//	Sum of squared differences from the pooled average: each part's own, plus the spread between the parts
+	squares := max(0, countA-1)*a.Variance + max(0, countB-1)*b.Variance +
+		countA*countB/count*(a.Score-b.Score)*(a.Score-b.Score)
+	variance := 0.0
+	if numThrows > 1 {
+		variance = squares / (count - 1)
+	}
+	return TargetResult{Position: b.Position, Score: average, Variance: variance, NumThrows: numThrows}
 }
 
 // FilterToOneTargetEach returns a slice of OneResult objects, with only one result for each target position
@@ -0,0 +1,92 @@
+package target_search
+
+//	Score curves show, for a few fixed targets and for the best target found, how the expected score falls as
+//	the standard deviation grows.  Charted together, they show where each curve overtakes another - the
+//	crossovers of a sigma sweep - and how much is lost by aiming at the wrong one near a given accuracy.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
+)
+
+// ChartTarget is a fixed target whose expected score is charted against the standard deviation
+type ChartTarget struct {
+	Name     string
+	Position boardgeo.BoardPosition
+}
+
+// ScoreCurve is the expected score of one target at each standard deviation of a sweep
+type ScoreCurve struct {
+	Name    string
+	StdDevs []float64
+	Scores  []float64
+}
+
+// BestTargetCurveName is the name of the curve of the best target found at each standard deviation
+const BestTargetCurveName = "Best Target"
+
+// DefaultChartTargets returns the targets usually compared: the centres of treble 20 and treble 19, and the bull
+func DefaultChartTargets() []ChartTarget {
+	return []ChartTarget{
+		{Name: "Treble 20", Position: RegionCentre(20, 3)},
+		{Name: "Treble 19", Position: RegionCentre(19, 3)},
+		{Name: "Bull", Position: boardgeo.CreateBoardPositionFromPolar(0, 0)},
+	}
+}
+
+// RegionCentre returns the centre of the scoring region with the given segment and multiplier (for example 20 and
+// 3 for treble 20), midway across the ring and midway around the segment, or the centre of the board if there is
+// no such region
+func RegionCentre(segment int, multiplier int) boardgeo.BoardPosition {
+	for _, region := range scoringRegions {
+		if region.SegmentValue == segment && region.Multiplier == multiplier && region.EndAngle-region.StartAngle < 360 {
+			angle := (region.StartAngle + region.EndAngle) / 2
+			if angle < 0 {
+				angle += 360
+			}
+			return boardgeo.CreateBoardPositionFromPolar((region.InnerRadius+region.OuterRadius)/2, angle)
+		}
+	}
+	return boardgeo.CreateBoardPositionFromPolar(0, 0)
+}
+
+// SweepScoreCurves returns the expected score of each of the given targets at each standard deviation of a sweep,
+// followed by the curve of the best target found at each.  The scores are exact for a Gaussian model, and from
+// the whole-board score grid otherwise.  The model itself is not changed.  If the seed isn't 0, each score grid
+// is built from throws seeded by it, as in the sweep itself.
+func SweepScoreCurves(model simulation.AccuracyModel, points []SigmaSweepPoint, targets []ChartTarget, seed uint64) ([]ScoreCurve, error) {
+	curves := make([]ScoreCurve, 0, len(targets)+1)
+	for _, target := range targets {
+		curves = append(curves, ScoreCurve{
+			Name:    target.Name,
+			StdDevs: make([]float64, 0, len(points)),
+			Scores:  make([]float64, 0, len(points)),
+		})
+	}
+	best := ScoreCurve{
+		Name:    BestTargetCurveName,
+		StdDevs: make([]float64, 0, len(points)),
+		Scores:  make([]float64, 0, len(points)),
+	}
+
+	for _, point := range points {
+		sweepModel := modelAtStdDev(model, point.StdDev, seed)
+		var scoreAt func(target boardgeo.BoardPosition) float64
+		if gaussianModel, isGaussian := sweepModel.(simulation.GaussianAccuracyModel); isGaussian {
+			scoreAt = func(target boardgeo.BoardPosition) float64 { return ExpectedScore(target, gaussianModel) }
+		} else {
+			scoreGrid, err := NewScoreGrid(sweepModel, DefaultScoreGridCells)
+			if err != nil {
+				return nil, err
+			}
+			scoreAt = scoreGrid.ScoreAt
+		}
+		for i, target := range targets {
+			curves[i].StdDevs = append(curves[i].StdDevs, point.StdDev)
+			curves[i].Scores = append(curves[i].Scores, scoreAt(target.Position))
+		}
+		best.StdDevs = append(best.StdDevs, point.StdDev)
+		best.Scores = append(best.Scores, point.Best.Score)
+	}
+	return append(curves, best), nil
+}
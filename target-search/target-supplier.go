@@ -2,7 +2,6 @@ package target_search
 
 import (
 	boardgeo "DStratMC/board-geometry"
-	"image"
 	"math"
 )
 
@@ -27,8 +26,6 @@ type CircularTargetSupplierInstance struct {
 	nextAngle       float64
 	radiusIncrement float64
 	angleIncrement  float64
-	squareDimension float64
-	imageMinPoint   image.Point
 }
 
 //const radiusIncrement = 0.1
@@ -37,15 +34,21 @@ type CircularTargetSupplierInstance struct {
 const radiusIncrement = 0.03
 const angleIncrement = 0.5
 
-// NewTargetSupplier creates a new instance of CircularTargetSupplierInstance, with the given squareDimension
-func NewTargetSupplier(squareDimension float64, imageMinPoint image.Point) TargetSupplier {
+// NewTargetSupplier creates a new instance of CircularTargetSupplierInstance.  Targets are generated in
+// normalized board coordinates, so no information about the displayed board is needed
+func NewTargetSupplier() TargetSupplier {
+	return NewTargetSupplierWithIncrements(radiusIncrement, angleIncrement)
+}
+
+// NewTargetSupplierWithIncrements creates a new instance of CircularTargetSupplierInstance with the
+// given grid resolution: the step in normalized radius between circles, and the step in degrees
+// between targets on each circle
+func NewTargetSupplierWithIncrements(radiusStep float64, angleStep float64) TargetSupplier {
 	instance := &CircularTargetSupplierInstance{
 		nextRadius:      0.0,
 		nextAngle:       0.0,
-		radiusIncrement: radiusIncrement,
-		angleIncrement:  angleIncrement,
-		squareDimension: squareDimension,
-		imageMinPoint:   imageMinPoint,
+		radiusIncrement: radiusStep,
+		angleIncrement:  angleStep,
 	}
 	return instance
 }
@@ -92,3 +95,38 @@ func AddWithoutNoise(a float64, b float64) float64 {
 	rounded := math.Round(sum*noiseDecimalPlacesFactor) / noiseDecimalPlacesFactor
 	return rounded
 }
+
+// 	ListTargetSupplierInstance is an implementation of TargetSupplier that returns a given list of targets,
+//	in order.  It is used to search a small set of chosen targets, such as the neighbourhoods of the best
+//	targets from an earlier, coarser search.
+
+type ListTargetSupplierInstance struct {
+	targets   []boardgeo.BoardPosition
+	nextIndex int
+}
+
+// NewListTargetSupplier creates a new instance of ListTargetSupplierInstance that supplies the given targets.
+// The targets should be unique.
+func NewListTargetSupplier(targets []boardgeo.BoardPosition) TargetSupplier {
+	instance := &ListTargetSupplierInstance{
+		targets:   targets,
+		nextIndex: 0,
+	}
+	return instance
+}
+
+func (t *ListTargetSupplierInstance) ForecastNumTargets() int32 {
+	return int32(len(t.targets))
+}
+
+// HasNext returns true if there are more targets to return
+func (t *ListTargetSupplierInstance) HasNext() bool {
+	return t.nextIndex < len(t.targets)
+}
+
+// NextTarget returns the next target in the list
+func (t *ListTargetSupplierInstance) NextTarget() boardgeo.BoardPosition {
+	result := t.targets[t.nextIndex]
+	t.nextIndex++
+	return result
+}
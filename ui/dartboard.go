@@ -8,6 +8,7 @@ package ui
 
 import (
 	boardgeo "DStratMC/board-geometry"
+	"DStratMC/simulation"
 	"fmt"
 	g "github.com/AllenDang/giu"
 	"image"
@@ -23,9 +24,18 @@ const targetCrossThickness = 2
 const hitMarkerAlpha = 200
 
 const accuracyCircleThickness = 2
+const ellipseDrawingSegments = 72
 
 var accuracyCircleColour = color.RGBA{R: 100, G: 100, B: 255, A: 192}
 
+// Size and position of the heat map's legend, a colour bar in the top right corner of the board square
+const heatMapLegendWidth = 16
+const heatMapLegendHeight = 200
+const heatMapLegendMargin = 20
+const heatMapLegendSteps = 50
+
+var heatMapLegendTextColour = color.RGBA{R: 230, G: 230, B: 230, A: 255}
+
 // Dartboard models the dartboard as an object to keep control
 // of the variables associated with it
 type Dartboard interface {
@@ -43,14 +53,16 @@ type Dartboard interface {
 	QueueHitMarker(hit boardgeo.BoardPosition, markerRadius int)
 	AllocateHitsSpace(i int)
 	SetDrawRefLines(checkbox bool)
-	SetDrawOneSigma(draw bool, radius float64)
-	SetDrawTwoSigma(draw bool, radius float64)
-	SetDrawThreeSigma(draw bool, radius float64)
+	SetDrawOneSigma(draw bool, ellipse simulation.SigmaEllipse)
+	SetDrawTwoSigma(draw bool, ellipse simulation.SigmaEllipse)
+	SetDrawThreeSigma(draw bool, ellipse simulation.SigmaEllipse)
 	SetStdDeviationCirclesCentre(position boardgeo.BoardPosition)
 	StartTracingCircleAtCenter(position boardgeo.BoardPosition)
 	GetTracingCircleCenter() boardgeo.BoardPosition
 	SetTracingCircleRadius(radius int)
 	StopTracingCircle()
+	SetHeatMap(texture *g.Texture, lowestScore float64, highestScore float64)
+	SetDrawHeatMap(draw bool)
 }
 
 type DartboardInstance struct {
@@ -87,24 +99,30 @@ type DartboardInstance struct {
 	// Draw the testing crosshair?
 	drawReferenceLines bool
 
-	//	Draw reference circles for 1, 2, and 3 standard deviations?
+	//	Draw reference ellipses (circles, for the simple normal model) for 1, 2, and 3 standard deviations?
 	stdDeviationCirclesCentre boardgeo.BoardPosition
 	stdDevClicked             bool
 
 	drawOneStdDeviation bool
-	drawOneStdRadius    float64
+	drawOneStdEllipse   simulation.SigmaEllipse
 
 	drawTwoStdDeviation bool
-	drawTwoStdRadius    float64
+	drawTwoStdEllipse   simulation.SigmaEllipse
 
 	drawThreeStdDeviation bool
-	drawThreeStdRadius    float64
+	drawThreeStdEllipse   simulation.SigmaEllipse
 
 	//	We might be called upon to trace a circle while the mouse is down.
 	//  We'll be told the centre point of this circle, and then draw from there
 	//  to a given radius (in pixels).  This state is on when radius is non-zero
 	traceCircleDrawCentre boardgeo.BoardPosition
 	traceCircleDrawRadius int
+
+	//	Colour map of the average scores found by the last search, and the scores at the ends of its colour scale
+	drawHeatMap    bool
+	heatMapTexture *g.Texture
+	heatMapLowest  float64
+	heatMapHighest float64
 }
 
 // NewDartboard creates an instance of the dartboard object
@@ -173,22 +191,35 @@ func (d *DartboardInstance) SetStdDeviationCirclesCentre(position boardgeo.Board
 	d.stdDevClicked = true
 }
 
-// SetDrawOneSigma records the information to draw a circle representing 1 standard deviation from centre
-func (d *DartboardInstance) SetDrawOneSigma(draw bool, radius float64) {
+// SetDrawOneSigma records the information to draw an ellipse representing 1 standard deviation from centre
+func (d *DartboardInstance) SetDrawOneSigma(draw bool, ellipse simulation.SigmaEllipse) {
 	d.drawOneStdDeviation = draw
-	d.drawOneStdRadius = radius
+	d.drawOneStdEllipse = ellipse
 }
 
-// SetDrawTwoSigma records the information to draw a circle representing 2 standard deviations from centre
-func (d *DartboardInstance) SetDrawTwoSigma(draw bool, radius float64) {
+// SetDrawTwoSigma records the information to draw an ellipse representing 2 standard deviations from centre
+func (d *DartboardInstance) SetDrawTwoSigma(draw bool, ellipse simulation.SigmaEllipse) {
 	d.drawTwoStdDeviation = draw
-	d.drawTwoStdRadius = radius
+	d.drawTwoStdEllipse = ellipse
 }
 
-// SetDrawThreeSigma records the information to draw a circle representing 3 standard deviations from centre
-func (d *DartboardInstance) SetDrawThreeSigma(draw bool, radius float64) {
+// SetDrawThreeSigma records the information to draw an ellipse representing 3 standard deviations from centre
+func (d *DartboardInstance) SetDrawThreeSigma(draw bool, ellipse simulation.SigmaEllipse) {
 	d.drawThreeStdDeviation = draw
-	d.drawThreeStdRadius = radius
+	d.drawThreeStdEllipse = ellipse
+}
+
+// SetHeatMap records the heat map image of search results to be laid over the scoring area, and the average
+// scores at the two ends of its colour scale.  A nil texture removes the heat map.
+func (d *DartboardInstance) SetHeatMap(texture *g.Texture, lowestScore float64, highestScore float64) {
+	d.heatMapTexture = texture
+	d.heatMapLowest = lowestScore
+	d.heatMapHighest = highestScore
+}
+
+// SetDrawHeatMap requests whether the heat map, if there is one, should be drawn
+func (d *DartboardInstance) SetDrawHeatMap(draw bool) {
+	d.drawHeatMap = draw
 }
 
 // SetDrawRefLines requests whether crosshair reference lines should be drawn through the board centre
@@ -271,6 +302,10 @@ func (d *DartboardInstance) DrawFunction() {
 	// Display dartboard image
 	canvas.AddImage(d.texture, d.imageMin, d.imageMax)
 
+	if d.drawHeatMap && d.heatMapTexture != nil {
+		d.drawHeatMapOverlay(canvas)
+	}
+
 	if d.drawReferenceLines {
 		d.drawReferenceLinesOnDartboard(canvas)
 	}
@@ -318,6 +353,43 @@ func (d *DartboardInstance) drawReferenceLinesOnDartboard(canvas *g.Canvas) {
 	canvas.AddLine(horizontalFrom, horizontalTo, crossHairColour, 1)
 }
 
+// drawHeatMapOverlay stretches the heat map image over the scoring area, and draws its legend: a bar of the
+// colour scale, labelled with the average scores at the top, middle, and bottom
+func (d *DartboardInstance) drawHeatMapOverlay(canvas *g.Canvas) {
+	xCentre := (d.imageMin.X + d.imageMax.X) / 2
+	yCentre := (d.imageMin.Y + d.imageMax.Y) / 2
+	radius := int(math.Round(d.GetScoringRadiusPixels()))
+	canvas.AddImage(d.heatMapTexture, image.Pt(xCentre-radius, yCentre-radius), image.Pt(xCentre+radius, yCentre+radius))
+
+	legendLeft := d.imageMax.X - heatMapLegendMargin - heatMapLegendWidth
+	legendTop := d.imageMin.Y + heatMapLegendMargin
+	stepHeight := float64(heatMapLegendHeight) / heatMapLegendSteps
+	for i := 0; i < heatMapLegendSteps; i++ {
+		//	Highest scores at the top
+		fraction := 1 - (float64(i)+0.5)/heatMapLegendSteps
+		top := legendTop + int(math.Round(float64(i)*stepHeight))
+		bottom := legendTop + int(math.Round(float64(i+1)*stepHeight))
+		canvas.AddRectFilled(image.Pt(legendLeft, top), image.Pt(legendLeft+heatMapLegendWidth, bottom),
+			heatMapColour(fraction, 255), 0, 0)
+	}
+	canvas.AddRect(image.Pt(legendLeft, legendTop), image.Pt(legendLeft+heatMapLegendWidth, legendTop+heatMapLegendHeight),
+		heatMapLegendTextColour, 0, 0, 1)
+
+	labels := []struct {
+		score float64
+		y     int
+	}{
+		{d.heatMapHighest, legendTop},
+		{(d.heatMapLowest + d.heatMapHighest) / 2, legendTop + heatMapLegendHeight/2},
+		{d.heatMapLowest, legendTop + heatMapLegendHeight},
+	}
+	for _, label := range labels {
+		text := fmt.Sprintf("%.1f", label.score)
+		labelWidth, labelHeight := g.CalcTextSize(text)
+		canvas.AddText(image.Pt(legendLeft-int(labelWidth)-4, label.y-int(labelHeight/2)), heatMapLegendTextColour, text)
+	}
+}
+
 // dartboardClicked is the callback function for the invisible button that covers the dartboard image
 // Here we determine where the mouse was and pass the click through to the provided callback function
 func (d *DartboardInstance) dartboardClicked() {
@@ -397,40 +469,58 @@ func (d *DartboardInstance) drawStdDevCircleInProgress(canvas *g.Canvas) {
 		float32(d.traceCircleDrawRadius), accuracyCircleColour, 0, accuracyCircleThickness)
 }
 
-// drawStdDeviationCircles draws the standard deviation circles that have been recorded
+// drawStdDeviationCircles draws the standard deviation ellipses that have been recorded
 func (d *DartboardInstance) drawStdDeviationCircles(canvas *g.Canvas) {
 	if d.stdDevClicked {
 		//fmt.Printf("std dev clicked, d = %#v\n", d)
 		if d.drawOneStdDeviation {
-			d.drawStdDeviationCircle(canvas, 1, "68", d.drawOneStdRadius)
+			d.drawStdDeviationEllipse(canvas, 1, "68", d.drawOneStdEllipse)
 		}
 		if d.drawTwoStdDeviation {
-			d.drawStdDeviationCircle(canvas, 2, "95", d.drawTwoStdRadius)
+			d.drawStdDeviationEllipse(canvas, 2, "95", d.drawTwoStdEllipse)
 		}
 		if d.drawThreeStdDeviation {
-			d.drawStdDeviationCircle(canvas, 3, "99.7", d.drawThreeStdRadius)
+			d.drawStdDeviationEllipse(canvas, 3, "99.7", d.drawThreeStdEllipse)
 		}
 	}
 }
 
-// drawStdDeviationCircle draws a circle representing a standard deviation from the centre
+// drawStdDeviationEllipse draws an ellipse representing a standard deviation from the centre
 //
-//	We draw a circle at the stored centre position, with the given radius, and label it with the
+//	We draw the ellipse (a circle for models with the same spread in all directions) at the stored centre
+//	position, as a closed path through points around its perimeter, and label the top of it with the
 //	multiplier and percentage of the normal distribution that it represents
-func (d *DartboardInstance) drawStdDeviationCircle(canvas *g.Canvas, multiplier float64, percentageString string, radius float64) {
-	// Draw the circle for this standard deviation reference
+func (d *DartboardInstance) drawStdDeviationEllipse(canvas *g.Canvas, multiplier float64, percentageString string, ellipse simulation.SigmaEllipse) {
 	xCentre, yCentre := boardgeo.GetXY(d.stdDeviationCirclesCentre, d.GetSquareDimension())
-	circlePosition := image.Pt(xCentre+d.imageMin.X, yCentre+d.imageMin.Y)
-	drawRadius := radius * d.GetSquareDimension() * boardgeo.ScoringAreaFraction / 2
-	canvas.AddCircle(circlePosition, float32(drawRadius), accuracyCircleColour, 0, accuracyCircleThickness)
+	centrePosition := image.Pt(xCentre+d.imageMin.X, yCentre+d.imageMin.Y)
+	pixelsPerUnit := d.GetSquareDimension() * boardgeo.ScoringAreaFraction / 2
+
+	//	Unit vectors along the major and minor axes, in board cartesian coordinates (y upwards)
+	majorAngleRadians := ellipse.MajorAxisAngle * math.Pi / 180
+	majorX, majorY := math.Sin(majorAngleRadians), math.Cos(majorAngleRadians)
+	minorX, minorY := majorY, -majorX
+
+	topY := centrePosition.Y
+	canvas.PathClear()
+	for i := 0; i < ellipseDrawingSegments; i++ {
+		t := 2 * math.Pi * float64(i) / ellipseDrawingSegments
+		along := ellipse.SemiMajorAxis * math.Cos(t)
+		across := ellipse.SemiMinorAxis * math.Sin(t)
+		boardX := along*majorX + across*minorX
+		boardY := along*majorY + across*minorY
+		// Screen y increases downwards
+		point := image.Pt(centrePosition.X+int(math.Round(boardX*pixelsPerUnit)),
+			centrePosition.Y-int(math.Round(boardY*pixelsPerUnit)))
+		topY = min(topY, point.Y)
+		canvas.PathLineTo(point)
+	}
+	canvas.PathStroke(accuracyCircleColour, g.DrawFlagsClosed, accuracyCircleThickness)
 
-	//	Label the top of the circle with the multiplier
+	//	Label the top of the ellipse with the multiplier
 	circleLabel := fmt.Sprintf("%2g std (%s%%)", multiplier, percentageString)
 	labelWidth, labelHeight := g.CalcTextSize(circleLabel)
 
-	labelPosition := circlePosition
-	labelPosition.X -= int(labelWidth / 2)
-	labelPosition.Y -= int(drawRadius + float64(labelHeight))
+	labelPosition := image.Pt(centrePosition.X-int(labelWidth/2), topY-int(labelHeight))
 	canvas.AddText(labelPosition, accuracyCircleColour, circleLabel)
 }
 
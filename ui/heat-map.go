@@ -0,0 +1,136 @@
+package ui
+
+//	The heat map is a semi-transparent colour map of the average score found by a search, laid over the
+//	scoring area of the board, so the player can see how flat or peaked the good regions are rather than
+//	only the single best point.  It is built once, when a search finishes, as an image with one pixel for
+//	each small square of the scoring area, coloured by the score of the nearest target that was searched.
+
+import (
+	boardgeo "DStratMC/board-geometry"
+	target_search "DStratMC/target-search"
+	"image"
+	"image/color"
+	"math"
+)
+
+// Number of pixels across the heat map image; it is stretched to the size of the displayed board
+const heatMapImageSize = 256
+
+// Opacity of the heat map, so the board can still be seen beneath it
+const heatMapAlpha = 150
+
+// The searched targets are sorted into this many buckets across the board, to find the nearest one quickly
+const heatMapBucketsAcross = 64
+
+// Colours of the heat map, from the lowest score to the highest, with colours blended between them
+var heatMapColourStops = []color.RGBA{
+	{R: 30, G: 30, B: 160},
+	{R: 0, G: 160, B: 220},
+	{R: 40, G: 200, B: 80},
+	{R: 250, G: 220, B: 0},
+	{R: 230, G: 30, B: 30},
+}
+
+// buildHeatMapImage draws the heat map of the given search results, and returns it with the lowest and
+// highest average scores, which are the ends of the colour scale
+func buildHeatMapImage(results []target_search.OneResult) (*image.RGBA, float64, float64) {
+	heatMap := image.NewRGBA(image.Rect(0, 0, heatMapImageSize, heatMapImageSize))
+	if len(results) == 0 {
+		return heatMap, 0, 0
+	}
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, result := range results {
+		lowest = math.Min(lowest, result.Score)
+		highest = math.Max(highest, result.Score)
+	}
+
+	buckets := bucketResults(results)
+	for row := 0; row < heatMapImageSize; row++ {
+		for col := 0; col < heatMapImageSize; col++ {
+			//	Pixel centre in board cartesian coordinates; the image spans the scoring area, with y upwards
+			x := -1 + (float64(col)+0.5)*2/heatMapImageSize
+			y := 1 - (float64(row)+0.5)*2/heatMapImageSize
+			if math.Hypot(x, y) > 1 {
+				continue
+			}
+			score := nearestResultScore(buckets, x, y)
+			fraction := 0.0
+			if highest > lowest {
+				fraction = (score - lowest) / (highest - lowest)
+			}
+			heatMap.SetRGBA(col, row, heatMapColour(fraction, heatMapAlpha))
+		}
+	}
+	return heatMap, lowest, highest
+}
+
+// heatMapColour returns the colour for a position (0 to 1) along the colour scale, with the given opacity.
+// The colour is not premultiplied by the opacity: the image's bytes go straight to a texture, which is drawn
+// with ordinary alpha blending.
+func heatMapColour(fraction float64, alpha uint8) color.RGBA {
+	scaled := math.Max(0, math.Min(1, fraction)) * float64(len(heatMapColourStops)-1)
+	lower := min(int(scaled), len(heatMapColourStops)-2)
+	blend := scaled - float64(lower)
+	from := heatMapColourStops[lower]
+	to := heatMapColourStops[lower+1]
+	channel := func(a uint8, b uint8) uint8 {
+		return uint8(math.Round((1-blend)*float64(a) + blend*float64(b)))
+	}
+	return color.RGBA{R: channel(from.R, to.R), G: channel(from.G, to.G), B: channel(from.B, to.B), A: alpha}
+}
+
+// resultBuckets holds the search results sorted into a square grid of buckets by position
+type resultBuckets [][]target_search.OneResult
+
+// bucketIndex returns the bucket (row and column) containing a cartesian point
+func bucketIndex(x float64, y float64) (int, int) {
+	col := int((x + 1) / 2 * heatMapBucketsAcross)
+	row := int((1 - y) / 2 * heatMapBucketsAcross)
+	return max(0, min(heatMapBucketsAcross-1, row)), max(0, min(heatMapBucketsAcross-1, col))
+}
+
+func bucketResults(results []target_search.OneResult) resultBuckets {
+	buckets := make(resultBuckets, heatMapBucketsAcross*heatMapBucketsAcross)
+	for _, result := range results {
+		x, y := boardgeo.GetCartesian(result.Position)
+		row, col := bucketIndex(x, y)
+		buckets[row*heatMapBucketsAcross+col] = append(buckets[row*heatMapBucketsAcross+col], result)
+	}
+	return buckets
+}
+
+// nearestResultScore returns the score of the search result nearest to the given point
+//
+//	We look in the point's own bucket and the ring of buckets around it, widening the search ring by ring
+//	until a result is found.  (A result in the next ring out could be nearer than one found in a corner of
+//	this ring, so we take one more ring once something is found.)
+func nearestResultScore(buckets resultBuckets, x float64, y float64) float64 {
+	centreRow, centreCol := bucketIndex(x, y)
+	bestDistance := math.Inf(1)
+	bestScore := 0.0
+	extraRings := -1
+	for ring := 0; ring < heatMapBucketsAcross && extraRings != 0; ring++ {
+		for row := centreRow - ring; row <= centreRow+ring; row++ {
+			for col := centreCol - ring; col <= centreCol+ring; col++ {
+				onRing := row == centreRow-ring || row == centreRow+ring || col == centreCol-ring || col == centreCol+ring
+				if !onRing || row < 0 || col < 0 || row >= heatMapBucketsAcross || col >= heatMapBucketsAcross {
+					continue
+				}
+				for _, result := range buckets[row*heatMapBucketsAcross+col] {
+					resultX, resultY := boardgeo.GetCartesian(result.Position)
+					distance := math.Hypot(resultX-x, resultY-y)
+					if distance < bestDistance {
+						bestDistance = distance
+						bestScore = result.Score
+					}
+				}
+			}
+		}
+		if extraRings > 0 {
+			extraRings--
+		} else if extraRings < 0 && !math.IsInf(bestDistance, 1) {
+			extraRings = 1
+		}
+	}
+	return bestScore
+}
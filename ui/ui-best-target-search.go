@@ -9,21 +9,16 @@ import (
 	"DStratMC/simulation"
 	target_search "DStratMC/target-search"
 	"context"
+	"errors"
 	"fmt"
 	g "github.com/AllenDang/giu"
 	"runtime"
-	"sync"
 	"time"
 )
 
+// The legacy single-threaded search is now simply the search engine run with one worker
 const use_legacy_single_threaded_search = false
 
-// Empirically, more threads than this isn't faster because of coordination costs.
-// In a future version, we will try to make the threads more independent by accepting large
-// blocks of targets and returning large blocks of results
-
-const num_search_workers = 4
-
 //	startSearchForBestThrow begins the search.  We spawn two sub-processes, to keep this, the mac-binary process,
 //	running to keep the UI responsive.  One subprocess is the actual search, and the other cycles the flag
 //	that displays the "searching, please wait" message on and off periodically
@@ -31,6 +26,7 @@ const num_search_workers = 4
 func (u *UserInterfaceInstance) startSearchForBestThrow(model simulation.AccuracyModel, numThrows int32) {
 	u.searchResultStrings = [10]string{"", "", "", "", "", "", "", "", "", ""}
 	u.dartboard.RemoveThrowMarkers()
+	u.dartboard.SetHeatMap(nil, 0, 0)
 	u.searchComplete = false
 	timeBeforeSearch := time.Now()
 
@@ -70,37 +66,72 @@ func (u *UserInterfaceInstance) cycleBlinkFlag(ctx context.Context, doneCallback
 
 // searchProcess is the subprocess that runs the actual target search.
 func (u *UserInterfaceInstance) searchProcess(ctx context.Context, model simulation.AccuracyModel, numThrows int32) {
-	//	Get target iterator and results aggregator
-	targetSupplier := target_search.NewTargetSupplier(u.dartboard.GetSquareDimension(), u.dartboard.GetImageMinPoint())
-	results := target_search.NewSimResults()
+	//	Get target iterator and the search engine that will throw at each target
+	targetSupplier := target_search.NewTargetSupplier()
+	//	Each worker has its own copy of the model and its own random numbers, so they scale with the CPUs
+	numWorkers := runtime.NumCPU()
+	if use_legacy_single_threaded_search {
+		numWorkers = 1
+	}
+	fmt.Println("Search starting with", numWorkers, "workers on", runtime.NumCPU(), "CPUs")
+	engine := target_search.NewSearchEngine(model, targetSupplier, numThrows, numWorkers)
+	if u.adaptiveSearchCheckbox {
+		engine = target_search.NewAdaptiveSearchEngine(model, int64(u.adaptiveBudgetField), numWorkers)
+	}
+	if u.halvingSearchCheckbox {
+		engine = target_search.NewHalvingSearchEngine(model, targetSupplier, numThrows, numWorkers)
+	}
+	//	Exact scores can't be calculated for a model with no formula for its distribution
+	scoringMethod := u.searchScoringMethod
+	if _, isGaussian := model.(simulation.GaussianAccuracyModel); !isGaussian && scoringMethod == target_search.ScoringMethod_Analytic {
+		scoringMethod = target_search.ScoringMethod_MonteCarlo
+	}
+	engine.SetScoringMethod(scoringMethod)
+	engine.SetCommonRandomNumbers(u.commonThrowsCheckbox)
+	if u.searchSeedField != 0 {
+		engine.SetSeed(uint64(u.searchSeedField))
+	}
 	u.cancelSearchVisible = true
 	u.searchComplete = false
+	u.searchCancelled = false
 	u.messageDisplay = ""
 	u.scoreDisplay = ""
 	g.Update()
 
-	if use_legacy_single_threaded_search {
-		//	Try each target
-		u.loopThroughAllTargets(ctx, model, numThrows, targetSupplier, results)
-	} else {
-		u.multiThreadedSearch(ctx, model, numThrows, targetSupplier, results, num_search_workers)
-	}
+	results, err := engine.Run(ctx, func(fractionComplete float64, target boardgeo.BoardPosition) {
+		//	Provide visual feedback of what's going on
+		u.searchProgressPercent = fractionComplete
+		if u.searchShowEachTarget {
+			u.dartboard.QueueTargetMarker(target)
+		}
+	})
+	// Clear progress bar
+	u.searchProgressPercent = 0
 
-	if u.searchCancelled {
+	if err != nil {
+		u.searchCancelled = errors.Is(err, context.Canceled)
 		u.dartboard.RemoveThrowMarkers()
-		u.searchProgressPercent = 0
-		u.messageDisplay = "Search cancelled"
+		if u.searchCancelled {
+			u.messageDisplay = "Search cancelled"
+		} else {
+			fmt.Println("Search failed:", err)
+			u.messageDisplay = "Search failed"
+		}
 	} else {
 		//	Get results, sorted from best to worst
 		if results.GetNumResults() > 0 {
 
 			sortedResults := results.GetResultsSortedByHighScore()
 
-			//  Filter results so each plain-language target is named only once
-			u.simResultsOneEach = target_search.FilterToOneTargetEach(sortedResults)
+			//  Filter results so each plain-language target is named only once, and note which of them
+			//	can't be told apart from the best
+			u.simResultsOneEach = target_search.MarkTiesWithBest(target_search.FilterToOneTargetEach(sortedResults))
 
 			// Messages saying what were the best targets
-			u.reportResults()
+			u.reportResults(model)
+
+			//	Colour map of the scores over the whole board
+			u.showHeatMap(sortedResults)
 
 			//	Draw best target on the board
 			bestTargetPosition := u.simResultsOneEach[0].Position
@@ -116,167 +147,42 @@ func (u *UserInterfaceInstance) searchProcess(ctx context.Context, model simulat
 	g.Update()
 }
 
-// loopThroughAllTargets uses the target supplier iterator to loop through every possible target, and throw
-// a large number of darts at each, recording the average score for each
-func (u *UserInterfaceInstance) loopThroughAllTargets(ctx context.Context,
-	model simulation.AccuracyModel,
-	numThrows int32,
-	targetSupplier target_search.TargetSupplier,
-	results target_search.SimResults) {
-	u.searchProgressPercent = 0
-	// Loop through all targets
-	targetCount := float64(0)
-	howManyTargetsExpected := targetSupplier.ForecastNumTargets()
-	for targetSupplier.HasNext() {
-		select {
-		case <-ctx.Done():
-			fmt.Println("Search cancelled")
-			u.searchCancelled = true
-			return
-		default:
-			//	Provide visual feedback of what's going on
-			targetCount += 1
-			u.searchProgressPercent = targetCount / float64(howManyTargetsExpected)
-			target := targetSupplier.NextTarget()
-			// Mark this target on the dartboard
-			if u.searchShowEachTarget {
-				u.dartboard.QueueTargetMarker(target)
-			}
-			g.Update()
-			// Do a large number of throws at this target
-			averageScore, err := u.multipleThrowsAtTarget(target, model, numThrows)
-			if err != nil {
-				fmt.Printf("Error throwing at target %v: %v", target, err)
-				continue
-			}
-			//	record result for this target
-			results.AddTargetResult(target_search.TargetResult{Position: target, Score: averageScore})
-		}
-	}
-	// Clear progress bar
-	u.searchProgressPercent = 0
-	g.Update()
-}
-
 // reportResults reports the results of the simulation by console messages and by setting the
-// ui variables that will be displayed for the best 10 targets
-func (u *UserInterfaceInstance) reportResults() {
+// ui variables that will be displayed for the best 10 targets.  If the model has a systematic bias,
+// each target is described by where the darts will be centred and how to adjust the aim for the bias.
+// Simulated averages are shown with their standard errors, and those that are statistically tied with
+// the best are marked with an asterisk.
+func (u *UserInterfaceInstance) reportResults(model simulation.AccuracyModel) {
+	offsetX, offsetY := model.GetMeanOffset()
+	u.searchResultsTied = false
 	for i := 0; i < 10; i++ {
-		_, score, description := boardgeo.DescribeBoardPoint(u.simResultsOneEach[i].Position)
-		fmt.Printf("   %s (theoretical score %d, average %g)\n", description, score, u.simResultsOneEach[i].Score)
-		u.searchResultStrings[i] = fmt.Sprintf("%s (%.2f)", description, u.simResultsOneEach[i].Score)
+		result := u.simResultsOneEach[i]
+		_, score, description := boardgeo.DescribeBoardPoint(result.Position)
+		if offsetX != 0 || offsetY != 0 {
+			description = target_search.DescribeAimCorrection(result.Position, offsetX, offsetY)
+		}
+		fmt.Printf("   %s (theoretical score %d, average %g, std error %g, tied with best %t)\n",
+			description, score, result.Score, result.StdError(), result.TiedWithBest)
+		averageString := fmt.Sprintf("%.2f", result.Score)
+		if result.NumThrows > 0 {
+			averageString = fmt.Sprintf("%.2f +/- %.2f", result.Score, result.StdError())
+		}
+		tieMarker := ""
+		if i > 0 && result.TiedWithBest {
+			tieMarker = " *"
+			u.searchResultsTied = true
+		}
+		u.searchResultStrings[i] = fmt.Sprintf("%s (%s)%s", description, averageString, tieMarker)
 	}
 	//	Setting the "search complete" flag allows the result labels to be displayed in the next UI loop pass
 	u.searchComplete = true
 }
 
-func (u *UserInterfaceInstance) multiThreadedSearch(
-	ctx context.Context,
-	model simulation.AccuracyModel,
-	throws int32,
-	supplier target_search.TargetSupplier,
-	results target_search.SimResults,
-	numWorkers uint16) {
-	fmt.Println("multiThreadedSearch starting")
-	fmt.Println("  num workers: ", numWorkers)
-	fmt.Println("  CPUs: ", runtime.NumCPU())
-	gm := runtime.GOMAXPROCS(int(numWorkers))
-	fmt.Println("  GOMAXPROCS: ", gm)
-	//fmt.Println("  throws: ", throws)
-	//fmt.Println("  supplier: ", supplier)
-	//fmt.Println("  results: ", results)
-
-	// Done counters
-	var wg sync.WaitGroup
-
-	// Channels to send targets and receive results
-	channelCapacity := supplier.ForecastNumTargets()
-	targetsChannel := make(chan boardgeo.BoardPosition, channelCapacity)
-	resultsChannel := make(chan target_search.TargetResult, channelCapacity)
-
-	//	Start worker threads
-	for i := uint16(0); i < numWorkers; i++ {
-		//fmt.Printf("  Starting worker number %d\n", i+1)
-		wg.Add(1)
-		go u.workerThread(i, ctx, model, throws, targetsChannel, resultsChannel, &wg)
-	}
-
-	// Fill the targets channel
-	//fmt.Println("Filling targets channel")
-	for supplier.HasNext() {
-		target := supplier.NextTarget()
-		targetsChannel <- target
-	}
-	close(targetsChannel)
-
-	// Wait for all worker threads to finish
-	go func() {
-		//fmt.Println("Starting mini-task to wait for threads to finish")
-		wg.Wait()
-		//fmt.Println("All worker threads finished, closing results channel")
-		close(resultsChannel)
-	}()
-
-	// Read the results as they come in
-	//fmt.Println("Reading results")
-	numResults := 0
-	totalResultsDenominator := float64(channelCapacity)
-reader:
-	for {
-		select {
-		case <-ctx.Done():
-			//fmt.Println("Search cancelled")
-			u.searchCancelled = true
-			break reader
-		case result, ok := <-resultsChannel:
-			if ok {
-				//fmt.Printf("  Got result %v\n", result)
-				results.AddTargetResult(result)
-				numResults += 1
-				u.searchProgressPercent = float64(numResults) / totalResultsDenominator
-			} else {
-				//fmt.Println("Results channel closed")
-				break reader
-			}
-		}
-	}
-	u.cancelBlinkTimer()
-	// Clear progress bar
-	u.searchProgressPercent = 0
-	g.Update()
-	//fmt.Println("multiThreadedSearch ending")
-}
-
-func (u *UserInterfaceInstance) workerThread(
-	threadNumber uint16,
-	ctx context.Context,
-	model simulation.AccuracyModel,
-	throws int32,
-	targetsChannel chan boardgeo.BoardPosition,
-	resultsChannel chan target_search.TargetResult,
-	wg *sync.WaitGroup) {
-	defer wg.Done()
-	fmt.Printf("Worker thread %d starting\n", threadNumber)
-
-	//fmt.Printf("Worker %d requesting work from targets channel\n", threadNumber)
-	for {
-		select {
-		case <-ctx.Done():
-			//fmt.Printf("Worker %d thread ending", threadNumber)
-			return
-		case target, ok := <-targetsChannel:
-			if ok {
-				//fmt.Printf("  Worker %d received target: %v\n", threadNumber, target)
-				averageScore, err := u.multipleThrowsAtTarget(target, model, throws)
-				if err != nil {
-					panic(err)
-				} else {
-					resultsChannel <- target_search.TargetResult{Position: target, Score: averageScore}
-				}
-			} else {
-				//fmt.Printf("  Worker %d, supply channel closed\n", threadNumber)
-				return
-			}
-		}
-	}
+// showHeatMap builds the heat map of all the search results and passes it to the dartboard for display.
+// The image becomes a texture on the UI thread, so the dartboard receives it a moment later.
+func (u *UserInterfaceInstance) showHeatMap(results []target_search.OneResult) {
+	heatMap, lowest, highest := buildHeatMapImage(results)
+	g.EnqueueNewTextureFromRgba(heatMap, func(texture *g.Texture) {
+		u.dartboard.SetHeatMap(texture, lowest, highest)
+	})
 }
@@ -0,0 +1,56 @@
+package ui
+
+//	UI functions to save a checkout chart: the best route to finish from each score, worked out for the current
+//	accuracy model, as a page or image to print
+
+import (
+	checkout_chart "DStratMC/checkout-chart"
+	"DStratMC/dialog"
+	"DStratMC/game"
+	"errors"
+	"fmt"
+	g "github.com/AllenDang/giu"
+)
+
+// Starting score of the legs the checkout chart is worked out for; only the scores that can be finished in one
+// visit appear on the chart
+const checkoutChartStartingScore = 501
+
+// saveCheckoutChart asks for a file to save the chart in, then works out the chart in a goroutine, to keep the
+// UI responsive, and saves it in the format given by the file's extension (HTML if none is given).  The search
+// seed, if set, makes the chart repeatable.
+func (u *UserInterfaceInstance) saveCheckoutChart() {
+	filePath, err := dialog.File().Filter("Checkout chart (HTML, SVG, or PNG)", "html", "svg", "png").Save()
+	if errors.Is(err, dialog.ErrCancelled) || filePath == "" {
+		return
+	}
+	if err != nil {
+		fmt.Println("Error selecting file to save: ", err)
+		return
+	}
+	if !checkout_chart.IsChartFile(filePath) {
+		filePath += ".html"
+	}
+	model := u.accuracyModel.Clone()
+	if u.searchSeedField != 0 {
+		model.SetSeed(uint64(u.searchSeedField))
+	}
+	playerDescription := fmt.Sprintf("for standard deviation %.3f", u.stdDevInputField)
+	if u.recordedThrowsCheckbox && u.realThrows.GetNumThrows() > 0 {
+		playerDescription = "for the recorded throws"
+	}
+	u.messageDisplay = "Working out checkouts"
+	go func() {
+		defer g.Update()
+		policy, err := game.SolveCheckouts(model, game.NewX01Rules(checkoutChartStartingScore), game.CheckoutObjective_FewestDarts)
+		if err == nil {
+			err = checkout_chart.SaveChart(filePath, checkout_chart.NewCheckoutChart(policy, playerDescription))
+		}
+		if err != nil {
+			fmt.Println("Error saving checkout chart:", err)
+			u.messageDisplay = "Checkout chart failed"
+			return
+		}
+		u.messageDisplay = "Checkout chart saved"
+	}()
+}
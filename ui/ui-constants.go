@@ -25,6 +25,9 @@ const multipleHitMarkerRadius = 1
 const numThrowsTextWidth = 120
 const stdDevTextWidth = 64.0
 
+// Largest magnitude accepted for the bivariate model's correlation (exactly 1 would collapse the ellipse to a line)
+const maxCorrelation = 0.99
+
 // const uiFramePadVertical = 2
 const uiRadioButtonHeight = 22
 const uiCheckboxHeight = uiRadioButtonHeight
@@ -20,24 +20,6 @@ func (u *UserInterfaceInstance) resultButtonClicked(buttonIndex int) {
 	}
 }
 
-// oneThrowsAtTarget will throw multiple dart at the target position, and return the result using the given accuracy model
-func (u *UserInterfaceInstance) multipleThrowsAtTarget(target boardgeo.BoardPosition, model simulation.AccuracyModel, throws int32) (float64, error) {
-	var total float64 = 0.0
-	for i := 0; i < int(throws); i++ {
-		hit, err := model.GetThrow(target,
-			u.dartboard.GetScoringRadiusPixels(),
-			u.dartboard.GetSquareDimension(),
-			u.dartboard.GetImageMinPoint())
-		if err != nil {
-			return 0.0, err
-		}
-		_, score, _ := boardgeo.DescribeBoardPoint(hit)
-		total += float64(score)
-	}
-	average := total / float64(throws)
-	return average, nil
-}
-
 // oneThrowsAtTarget will throw a single dart at the target position, and return the result using the uniform distribution accuracy model
 // The results are added to the running total for calculating statistics, and marked on the board with a hit marker
 func (u *UserInterfaceInstance) oneUniformThrow(dartboard Dartboard, position boardgeo.BoardPosition, model simulation.AccuracyModel) {
@@ -50,10 +32,7 @@ func (u *UserInterfaceInstance) oneUniformThrow(dartboard Dartboard, position bo
 	dartboard.QueueAccuracyCircle(position, accuracyRadius)
 
 	//	Get a modeled hit within the accuracy
-	hit, err := model.GetThrow(position,
-		dartboard.GetScoringRadiusPixels(),
-		dartboard.GetSquareDimension(),
-		dartboard.GetImageMinPoint())
+	hit, err := model.GetThrow(position)
 	if err != nil {
 		fmt.Printf("Error getting throw %v", err)
 		return
@@ -89,10 +68,7 @@ func (u *UserInterfaceInstance) multipleUniformThrows(dartboard Dartboard, posit
 	u.throwTotal = 0
 	for i := 0; i < int(u.numThrowsField); i++ {
 		//	Get a modeled hit within the accuracy
-		hit, err := model.GetThrow(position,
-			dartboard.GetScoringRadiusPixels(),
-			dartboard.GetSquareDimension(),
-			dartboard.GetImageMinPoint())
+		hit, err := model.GetThrow(position)
 		if err != nil {
 			fmt.Printf("Error getting throw %v", err)
 			return
@@ -122,10 +98,7 @@ func (u *UserInterfaceInstance) oneNormalThrow(dartboard Dartboard, position boa
 	dartboard.SetStdDeviationCirclesCentre(position)
 
 	//	Get a modeled hit within the accuracy
-	hit, err := model.GetThrow(position,
-		dartboard.GetScoringRadiusPixels(),
-		dartboard.GetSquareDimension(),
-		dartboard.GetImageMinPoint())
+	hit, err := model.GetThrow(position)
 	if err != nil {
 		fmt.Printf("Error getting throw %v", err)
 		return
@@ -162,10 +135,7 @@ func (u *UserInterfaceInstance) multipleNormalThrows(dartboard Dartboard, positi
 
 	for i := 0; i < int(u.numThrowsField); i++ {
 		//	Get a modeled hit within the accuracy
-		hit, err := model.GetThrow(position,
-			dartboard.GetScoringRadiusPixels(),
-			dartboard.GetSquareDimension(),
-			dartboard.GetImageMinPoint())
+		hit, err := model.GetThrow(position)
 		if err != nil {
 			fmt.Printf("Error getting throw %v", err)
 			return
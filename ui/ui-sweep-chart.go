@@ -0,0 +1,118 @@
+package ui
+
+//	UI functions for the sigma sweep chart.  The sweep finds the best target at each of a range of standard
+//	deviations, and the chart, in its own window, shows the expected score of a few usual targets and of the best
+//	target against the standard deviation, with the crossovers and the player's own standard deviation marked.
+
+import (
+	"DStratMC/dialog"
+	sweep_chart "DStratMC/sweep-chart"
+	target_search "DStratMC/target-search"
+	"context"
+	"errors"
+	"fmt"
+	g "github.com/AllenDang/giu"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Range of standard deviations swept from the user interface
+const uiSweepFrom = 0.02
+const uiSweepTo = 0.30
+const uiSweepStep = 0.01
+
+// Size of the sweep chart window
+const sweepChartWindowWidth = 760
+const sweepChartWindowHeight = 520
+
+// startSigmaSweep runs the sweep and works out the chart curves in a goroutine, to keep the UI responsive,
+// and opens the chart window when they are ready
+func (u *UserInterfaceInstance) startSigmaSweep() {
+	if u.sweepRunning || !u.canSweepModel() {
+		return
+	}
+	u.sweepRunning = true
+	u.messageDisplay = "Sweeping, please wait"
+	model := u.accuracyModel.Clone()
+	seed := uint64(u.searchSeedField)
+	go func() {
+		defer func() {
+			u.sweepRunning = false
+			g.Update()
+		}()
+		stdDevs := target_search.SweepStdDevs(uiSweepFrom, uiSweepTo, uiSweepStep)
+		points, crossovers, err := target_search.SigmaSweep(context.Background(), model, stdDevs, runtime.NumCPU(), seed)
+		if err != nil {
+			fmt.Println("Sigma sweep failed:", err)
+			u.messageDisplay = "Sweep failed"
+			return
+		}
+		curves, err := target_search.SweepScoreCurves(model, points, target_search.DefaultChartTargets(), seed)
+		if err != nil {
+			fmt.Println("Sigma sweep failed:", err)
+			u.messageDisplay = "Sweep failed"
+			return
+		}
+		u.sweepCurves = curves
+		u.sweepCrossovers = crossovers
+		u.sweepAxisLabel = sweep_chart.StdDevAxisLabel(model)
+		u.showSweepChart = true
+		u.messageDisplay = ""
+	}()
+}
+
+// uiSweepChartWindow draws the chart window, if it is open, on top of the main window
+func (u *UserInterfaceInstance) uiSweepChartWindow() {
+	if !u.showSweepChart || len(u.sweepCurves) == 0 {
+		return
+	}
+	chart := sweep_chart.NewSweepChart(u.sweepCurves, u.sweepCrossovers, float64(u.stdDevInputField))
+	chart.XLabel = u.sweepAxisLabel
+	xMin, xMax, yMin, yMax := chart.Bounds()
+
+	plots := make([]g.PlotWidget, 0, len(chart.Series)+len(chart.Markers))
+	for _, series := range chart.Series {
+		plots = append(plots, g.LineXY(series.Name, series.X, series.Y))
+	}
+	//	Each marker is a vertical line, named so it appears in the legend
+	for _, marker := range chart.Markers {
+		if marker.X < xMin || marker.X > xMax {
+			continue
+		}
+		plots = append(plots, g.LineXY(marker.Label, []float64{marker.X, marker.X}, []float64{yMin, yMax}))
+	}
+
+	g.Window("Sigma Sweep").IsOpen(&u.showSweepChart).
+		Size(sweepChartWindowWidth, sweepChartWindowHeight).
+		Layout(
+			g.Plot(chart.Title).
+				SetXAxisLabel(g.AxisX1, chart.XLabel).
+				SetYAxisLabel(g.AxisY1, chart.YLabel).
+				AxisLimits(xMin, xMax, yMin, yMax, g.ConditionAlways).
+				Size(sweepChartWindowWidth-20, sweepChartWindowHeight-80).
+				Plots(plots...),
+			g.Row(
+				g.Button("Save PNG").OnClick(func() { u.saveSweepChart(chart, "PNG image", "png") }),
+				g.Button("Save SVG").OnClick(func() { u.saveSweepChart(chart, "SVG image", "svg") }),
+			),
+		)
+}
+
+// saveSweepChart asks for a file name and saves the chart there in the given format
+func (u *UserInterfaceInstance) saveSweepChart(chart sweep_chart.Chart, description string, extension string) {
+	filePath, err := dialog.File().Filter(description, extension).Save()
+	if errors.Is(err, dialog.ErrCancelled) || filePath == "" {
+		return
+	}
+	if err != nil {
+		fmt.Println("Error selecting file to save: ", err)
+		return
+	}
+	if !strings.EqualFold(filepath.Ext(filePath), "."+extension) {
+		filePath += "." + extension
+	}
+	if err := sweep_chart.SaveChart(filePath, chart); err != nil {
+		fmt.Println("Error saving sweep chart:", err)
+	}
+}
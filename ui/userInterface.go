@@ -62,18 +62,52 @@ type UserInterfaceInstance struct {
 	drawTwoSigma               bool
 	drawThreeSigma             bool
 
-	searchShowEachTarget  bool
-	searchProgressPercent float64
-	searchComplete        bool
-	searchResultStrings   [10]string
-	searchResultsRadio    int
-	searchingBlinkOn      bool
-	cancelSearchVisible   bool
-	cancelBlinkTimer      context.CancelFunc
-	cancelSearch          context.CancelFunc
-	searchCancelled       bool
-	simResultsOneEach     []target_search.OneResult
-	stdDevInputField      float32
+	searchShowEachTarget   bool
+	showHeatMapCheckbox    bool
+	searchScoringMethod    target_search.ScoringMethod
+	adaptiveSearchCheckbox bool  // Coarse-to-fine search with a budget of throws, rather than the full grid
+	adaptiveBudgetField    int32 // Total throws for the adaptive search
+	halvingSearchCheckbox  bool  // Successive halving, starting each target with the number of throws field
+	commonThrowsCheckbox   bool  // Throw the same set of darts at every target (common random numbers)
+	searchSeedField        int32 // Seed to make the search repeatable, or 0 for a different search each time
+	searchProgressPercent  float64
+	searchComplete         bool
+	searchResultStrings    [10]string
+	searchResultsRadio     int
+	searchResultsTied      bool // Some of the reported targets are statistically tied with the best
+	searchingBlinkOn       bool
+	cancelSearchVisible    bool
+	cancelBlinkTimer       context.CancelFunc
+	cancelSearch           context.CancelFunc
+	searchCancelled        bool
+	simResultsOneEach      []target_search.OneResult
+	stdDevInputField       float32
+
+	// Results of a sweep over a range of standard deviations, charted in their own window
+	sweepRunning    bool
+	showSweepChart  bool
+	sweepCurves     []target_search.ScoreCurve
+	sweepCrossovers []target_search.SigmaCrossover
+	sweepAxisLabel  string
+
+	// Optional separate vertical spread and correlation, for the bivariate normal model
+	separateXYCheckbox    bool
+	stdDevYInputField     float32
+	correlationInputField float32
+
+	// Optional broad distribution of occasional wild throws, for the mixture model
+	wildThrowsCheckbox     bool
+	wildFractionInputField float32
+	wildStdDevInputField   float32
+
+	// Optionally re-use the miss vectors of the recorded real throws, for the empirical model
+	recordedThrowsCheckbox       bool
+	smoothRecordedThrowsCheckbox bool
+
+	// Systematic bias (mean miss vector) measured from real throws, optionally applied to the model
+	applyAimBiasCheckbox bool
+	aimBiasX             float64
+	aimBiasY             float64
 
 	// Drawing circle to represent standard deviation
 	circleDrawingState drawCircleState
@@ -98,11 +132,17 @@ func NewUserInterface(loadedImage *image.RGBA) UserInterface {
 		drawTwoSigma:               false,
 		drawThreeSigma:             false,
 		searchShowEachTarget:       false,
+		showHeatMapCheckbox:        true,
 		searchResultStrings:        [10]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"},
 		dartboard:                  NewDartboard(),
 		drawReferenceLinesCheckbox: true,
 		numThrowsField:             throwsAtOneTarget,
+		adaptiveBudgetField:        target_search.DefaultAdaptiveThrowBudget,
 		stdDevInputField:           0.15,
+		stdDevYInputField:          0.15,
+		correlationInputField:      0,
+		wildFractionInputField:     0.05,
+		wildStdDevInputField:       0.5,
 		circleDrawingState:         drawCircleStateOff,
 		realThrows:                 simulation.NewRealThrowCollectionInstance(),
 	}
@@ -111,6 +151,7 @@ func NewUserInterface(loadedImage *image.RGBA) UserInterface {
 	})
 
 	instance.dartboard.SetDrawRefLines(instance.drawReferenceLinesCheckbox)
+	instance.dartboard.SetDrawHeatMap(instance.showHeatMapCheckbox)
 	instance.dartboard.SetClickCallback(instance.dartboardClickCallback)
 	return instance
 }
@@ -127,6 +168,7 @@ func (u *UserInterfaceInstance) MainUiLoop() {
 		u.leftToolbarLayout(),
 		g.Custom(u.dartboard.DrawFunction),
 	)
+	u.uiSweepChartWindow()
 
 	//	Click Callbacks are processed only when the mouse is released.  Because, for purposes of
 	//  tracing the standard deviation circle, we want to handle the cases of the mouse first
@@ -347,21 +389,67 @@ func (u *UserInterfaceInstance) uiLayoutMessagesPanel() g.Widget {
 }
 
 func (u *UserInterfaceInstance) uiLayoutNormalInfoPanel() g.Widget {
+	stdDevLabel := "StdDev 0-1"
+	if u.separateXYCheckbox {
+		stdDevLabel = "StdDev X"
+	}
 	fieldsLayout := g.Layout{
 		g.Label("Normal Distribution"),
 		g.Dummy(0, BlankLineHeight),
 		g.InputFloat(&u.stdDevInputField).
-			Label("StdDev 0-1").
+			Label(stdDevLabel).
 			Size(stdDevTextWidth).
 			OnChange(u.validateAndProcessStdDevField),
+		g.Style().SetDisabled(u.wildThrowsCheckbox).To(
+			g.Checkbox("Separate X/Y", &u.separateXYCheckbox).OnChange(u.refreshAccuracyModel),
+		),
+		g.Condition(u.separateXYCheckbox && !u.wildThrowsCheckbox,
+			g.Layout{
+				g.InputFloat(&u.stdDevYInputField).
+					Label("StdDev Y").
+					Size(stdDevTextWidth).
+					OnChange(u.validateAndProcessBivariateFields),
+				g.InputFloat(&u.correlationInputField).
+					Label("Correlation").
+					Size(stdDevTextWidth).
+					OnChange(u.validateAndProcessBivariateFields),
+			}, nil),
+		g.Checkbox("Wild Throws", &u.wildThrowsCheckbox).OnChange(u.refreshAccuracyModel),
+		g.Condition(u.wildThrowsCheckbox,
+			g.Layout{
+				g.InputFloat(&u.wildFractionInputField).
+					Label("Wild Fraction").
+					Size(stdDevTextWidth).
+					OnChange(u.validateAndProcessWildThrowFields),
+				g.InputFloat(&u.wildStdDevInputField).
+					Label("Wild StdDev").
+					Size(stdDevTextWidth).
+					OnChange(u.validateAndProcessWildThrowFields),
+			}, nil),
+		g.Style().SetDisabled(u.realThrows.GetNumThrows() == 0).To(
+			g.Checkbox("Recorded Throws", &u.recordedThrowsCheckbox).OnChange(u.refreshAccuracyModel),
+		),
+		g.Condition(u.recordedThrowsCheckbox,
+			g.Checkbox("Smoothed", &u.smoothRecordedThrowsCheckbox).OnChange(u.refreshAccuracyModel),
+			nil),
+		g.Style().SetDisabled(u.aimBiasX == 0 && u.aimBiasY == 0).To(
+			g.Checkbox("Apply Aim Bias", &u.applyAimBiasCheckbox),
+		),
 		g.Dummy(0, BlankLineHeight),
 		g.Label("Show circles for:"),
-		g.Checkbox("1 Sigma", &u.drawOneSigma).OnChange(func() { u.dartboard.SetDrawOneSigma(u.drawOneSigma, u.accuracyModel.GetSigmaRadius(1)) }),
-		g.Checkbox("2 Sigma", &u.drawTwoSigma).OnChange(func() { u.dartboard.SetDrawTwoSigma(u.drawTwoSigma, u.accuracyModel.GetSigmaRadius(2)) }),
-		g.Checkbox("3 Sigma", &u.drawThreeSigma).OnChange(func() { u.dartboard.SetDrawThreeSigma(u.drawThreeSigma, u.accuracyModel.GetSigmaRadius(3)) }),
+		g.Checkbox("1 Sigma", &u.drawOneSigma).OnChange(u.refreshSigmaContours),
+		g.Checkbox("2 Sigma", &u.drawTwoSigma).OnChange(u.refreshSigmaContours),
+		g.Checkbox("3 Sigma", &u.drawThreeSigma).OnChange(u.refreshSigmaContours),
+	}
+	numInputFields := 1
+	if u.wildThrowsCheckbox || u.separateXYCheckbox {
+		numInputFields = 3
+	}
+	numCheckboxes := 7
+	if u.recordedThrowsCheckbox {
+		numCheckboxes++
 	}
 	const numLabels = 4
-	const numCheckboxes = 3
 	return g.Condition(u.mode != Mode_Exact && u.mode != Mode_EmpricalStdDev,
 		g.Layout{
 			g.Style().
@@ -372,8 +460,8 @@ func (u *UserInterfaceInstance) uiLayoutNormalInfoPanel() g.Widget {
 					g.Child().Border(true).
 						Size(LeftToolbarChildWidth,
 							numLabels*uiLabelHeight+
-								numCheckboxes*uiCheckboxHeight+
-								uiInputFieldHeight-20).
+								float32(numCheckboxes)*uiCheckboxHeight+
+								float32(numInputFields)*uiInputFieldHeight-20).
 						Layout(fieldsLayout),
 				),
 		}, nil)
@@ -384,6 +472,37 @@ func (u *UserInterfaceInstance) uiSearchControlsPanel() g.Widget {
 		g.Label("Search Controls"),
 		g.Dummy(0, BlankLineHeight),
 		g.Checkbox("Show Search", &u.searchShowEachTarget),
+		g.Checkbox("Heat Map", &u.showHeatMapCheckbox).OnChange(func() {
+			u.dartboard.SetDrawHeatMap(u.showHeatMapCheckbox)
+		}),
+		g.RadioButton("Simulated Throws", u.searchScoringMethod == target_search.ScoringMethod_MonteCarlo).OnChange(func() {
+			u.searchScoringMethod = target_search.ScoringMethod_MonteCarlo
+		}),
+		g.Style().SetDisabled(!u.isGaussianModel()).To(
+			g.RadioButton("Exact Scores", u.searchScoringMethod == target_search.ScoringMethod_Analytic).OnChange(func() {
+				u.searchScoringMethod = target_search.ScoringMethod_Analytic
+			}),
+		),
+		g.RadioButton("Whole-Board Grid", u.searchScoringMethod == target_search.ScoringMethod_ScoreGrid).OnChange(func() {
+			u.searchScoringMethod = target_search.ScoringMethod_ScoreGrid
+		}),
+		g.Checkbox("Adaptive Search", &u.adaptiveSearchCheckbox).OnChange(func() {
+			u.halvingSearchCheckbox = u.halvingSearchCheckbox && !u.adaptiveSearchCheckbox
+		}),
+		g.Condition(u.adaptiveSearchCheckbox,
+			g.InputInt(&u.adaptiveBudgetField).Label("Budget").
+				Size(numThrowsTextWidth).
+				StepSize(1_000_000).
+				StepSizeFast(10_000_000).
+				OnChange(u.validateAdaptiveBudgetField),
+			nil),
+		g.Checkbox("Successive Halving", &u.halvingSearchCheckbox).OnChange(func() {
+			u.adaptiveSearchCheckbox = u.adaptiveSearchCheckbox && !u.halvingSearchCheckbox
+		}),
+		g.Checkbox("Same Throws Everywhere", &u.commonThrowsCheckbox),
+		g.InputInt(&u.searchSeedField).Label("Seed").
+			Size(numThrowsTextWidth).
+			OnChange(u.validateSearchSeedField),
 		g.Dummy(0, BlankLineHeight),
 		g.Button("START SEARCH").OnClick(func() {
 			u.startSearchForBestThrow(u.accuracyModel, u.numThrowsField)
@@ -393,6 +512,10 @@ func (u *UserInterfaceInstance) uiSearchControlsPanel() g.Widget {
 			fmt.Println("Cancelling Search")
 			u.cancelSearch()
 		}),
+		g.Style().SetDisabled(u.sweepRunning || !u.canSweepModel()).To(
+			g.Button("Sigma Sweep").OnClick(u.startSigmaSweep),
+		),
+		g.Button("Checkout Chart").OnClick(u.saveCheckoutChart),
 		g.Condition(u.searchingBlinkOn,
 			g.CSSTag("waitlabel").To(
 				g.Label("Searching, please wait"),
@@ -400,8 +523,12 @@ func (u *UserInterfaceInstance) uiSearchControlsPanel() g.Widget {
 			g.Dummy(0, BlankLineHeight)),
 	}
 	const numLabels = 4
-	const numCheckboxes = 1
-	const numButtons = 1
+	const numCheckboxes = 8 // Including the scoring method radio buttons, which are the same height
+	const numButtons = 3
+	numInputFields := 1 // The seed
+	if u.adaptiveSearchCheckbox {
+		numInputFields++
+	}
 	return g.Condition(u.mode == Mode_SearchNormal,
 		g.Layout{
 			g.Style().
@@ -414,7 +541,8 @@ func (u *UserInterfaceInstance) uiSearchControlsPanel() g.Widget {
 							numLabels*uiLabelHeight+
 								uiProgressBarHeight+
 								numButtons*uiButtonHeight+
-								numCheckboxes*uiCheckboxHeight+12).
+								numCheckboxes*uiCheckboxHeight+
+								float32(numInputFields)*uiInputFieldHeight+12).
 						Layout(fieldsLayout),
 				),
 		}, nil)
@@ -426,6 +554,7 @@ func (u *UserInterfaceInstance) uiRealThrowMeasurementControls() g.Widget {
 		g.Button("New Model").OnClick(func() {
 			fmt.Println("New Model")
 			u.realThrows = simulation.NewRealThrowCollectionInstance()
+			u.aimBiasX, u.aimBiasY = 0, 0
 			u.measurementState = measureStdDevStateSelectTarget
 			u.messageDisplay = "Click Target"
 		}),
@@ -438,6 +567,9 @@ func (u *UserInterfaceInstance) uiRealThrowMeasurementControls() g.Widget {
 		g.Dummy(0, BlankLineHeight),
 		g.Label(fmt.Sprintf("Data Points: %d", u.realThrows.GetNumThrows())),
 		g.Label(fmt.Sprintf("Std Dev: %s", u.realThrows.GetStdDevString())),
+		g.Label(fmt.Sprintf("Bias: %s", u.aimBiasString())),
+		u.uiLayoutFittedModel(),
+		u.uiLayoutFittedMixture(),
 		g.Dummy(0, BlankLineHeight),
 		g.Button("Load").OnClick(u.loadRealThrowData),
 		g.Style().SetDisabled(u.realThrows.GetNumThrows() == 0).To(
@@ -447,6 +579,86 @@ func (u *UserInterfaceInstance) uiRealThrowMeasurementControls() g.Widget {
 	return g.Condition(u.mode == Mode_EmpricalStdDev, fieldsLayout, nil)
 }
 
+// uiLayoutFittedModel displays the parameters of the 2D fit to the real throws, with their 95% confidence
+// intervals, and a button to use the fitted parameters as the normal model's X/Y standard deviations
+func (u *UserInterfaceInstance) uiLayoutFittedModel() g.Widget {
+	fit, err := u.realThrows.FitAccuracyModel()
+	if err != nil || !u.realThrows.IsStdDevAvailable() {
+		return nil
+	}
+	return g.Layout{
+		g.Label(fmt.Sprintf("StdDev X %s", formatEstimate(fit.StdDevX))),
+		g.Label(fmt.Sprintf("StdDev Y %s", formatEstimate(fit.StdDevY))),
+		g.Label(fmt.Sprintf("Corr %s", formatEstimate(fit.Correlation))),
+		g.Button("Use 2D Fit").OnClick(func() {
+			u.stdDevInputField = float32(fit.StdDevX.Value)
+			u.stdDevYInputField = float32(fit.StdDevY.Value)
+			u.correlationInputField = float32(max(-maxCorrelation, min(maxCorrelation, fit.Correlation.Value)))
+			u.separateXYCheckbox = true
+			u.aimBiasX, u.aimBiasY = fit.MeanOffsetX.Value, fit.MeanOffsetY.Value
+			u.messageDisplay = "Using 2D fit"
+		}),
+	}
+}
+
+// uiLayoutFittedMixture displays the fraction and spread of wild throws found by fitting the mixture model to
+// the real throws, and a button to use the fitted mixture for simulations
+func (u *UserInterfaceInstance) uiLayoutFittedMixture() g.Widget {
+	fit, err := u.realThrows.FitMixtureModel()
+	if err != nil {
+		return nil
+	}
+	return g.Layout{
+		g.Label(fmt.Sprintf("Wild %.1f%%, StdDev %.3f", 100*fit.OutlierWeight, fit.OutlierStdDev)),
+		g.Button("Use Wild Throw Fit").OnClick(func() {
+			u.stdDevInputField = float32(fit.CoreStdDev)
+			u.wildFractionInputField = float32(fit.OutlierWeight)
+			u.wildStdDevInputField = float32(min(1, fit.OutlierStdDev))
+			u.wildThrowsCheckbox = true
+			u.aimBiasX, u.aimBiasY = fit.MeanOffsetX, fit.MeanOffsetY
+			u.messageDisplay = "Using wild throw fit"
+		}),
+	}
+}
+
+// formatEstimate formats a fitted value and its confidence interval compactly, e.g. "0.052 (0.041-0.071)"
+func formatEstimate(estimate simulation.ParameterEstimate) string {
+	return fmt.Sprintf("%.3f (%.3f-%.3f)", estimate.Value, estimate.Lower, estimate.Upper)
+}
+
+// validateAndProcessBivariateFields checks the vertical standard deviation and correlation fields
+// used by the bivariate model, and redraws the standard deviation ellipses to match them
+func (u *UserInterfaceInstance) validateAndProcessBivariateFields() {
+	if u.stdDevYInputField < .00001 || u.stdDevYInputField > 1 {
+		u.stdDevYInputField = max(0.00001, min(1, u.stdDevYInputField))
+		u.messageDisplay = "StdDev must be 0 to 1"
+		return
+	}
+	if u.correlationInputField <= -1 || u.correlationInputField >= 1 {
+		u.correlationInputField = max(-maxCorrelation, min(maxCorrelation, u.correlationInputField))
+		u.messageDisplay = "Correlation must be -1 to 1"
+		return
+	}
+	u.messageDisplay = ""
+	u.refreshAccuracyModel()
+}
+
+// validateAndProcessWildThrowFields checks the fraction and spread of wild throws used by the mixture model
+func (u *UserInterfaceInstance) validateAndProcessWildThrowFields() {
+	if u.wildFractionInputField < 0 || u.wildFractionInputField > 1 {
+		u.wildFractionInputField = max(0, min(1, u.wildFractionInputField))
+		u.messageDisplay = "Wild Fraction must be 0 to 1"
+		return
+	}
+	if u.wildStdDevInputField < .00001 || u.wildStdDevInputField > 1 {
+		u.wildStdDevInputField = max(0.00001, min(1, u.wildStdDevInputField))
+		u.messageDisplay = "StdDev must be 0 to 1"
+		return
+	}
+	u.messageDisplay = ""
+	u.refreshAccuracyModel()
+}
+
 func (u *UserInterfaceInstance) validateAndProcessStdDevField() {
 	if u.stdDevInputField < .00001 {
 		u.stdDevInputField = 0
@@ -498,6 +710,24 @@ func (u *UserInterfaceInstance) validateNumThrowsField() {
 	u.messageDisplay = ""
 }
 
+func (u *UserInterfaceInstance) validateAdaptiveBudgetField() {
+	if u.adaptiveBudgetField < 1 {
+		u.adaptiveBudgetField = target_search.DefaultAdaptiveThrowBudget
+		u.messageDisplay = "Budget must be > 0"
+		return
+	}
+	u.messageDisplay = ""
+}
+
+func (u *UserInterfaceInstance) validateSearchSeedField() {
+	if u.searchSeedField < 0 {
+		u.searchSeedField = 0
+		u.messageDisplay = "Seed must be >= 0"
+		return
+	}
+	u.messageDisplay = ""
+}
+
 // uiLayoutAverageScore displays the average score from non-search clicks
 func (u *UserInterfaceInstance) uiLayoutAverageScore() g.Widget {
 	return g.Layout{
@@ -519,6 +749,9 @@ func (u *UserInterfaceInstance) uiLayoutSearchResults() g.Widget {
 			g.Layout{
 				g.Label(fmt.Sprintf("Best %d targets:", numSearchResultsToDisplay)),
 				u.uiLayoutSearchResultLabels(numSearchResultsToDisplay),
+				g.Condition(u.searchResultsTied,
+					g.Label("* Not significantly worse than the best"),
+					nil),
 			}, nil)}
 }
 
@@ -545,11 +778,11 @@ func (u *UserInterfaceInstance) getAccuracyModel(mode InterfaceMode) simulation.
 	case Mode_MultiAvg:
 		return simulation.NewUniformAccuracyModel(uniformCEPRadius)
 	case Mode_OneNormal:
-		return simulation.NewNormalAccuracyModel(float64(u.stdDevInputField))
+		return u.getNormalAccuracyModel()
 	case Mode_MultiNormal:
-		return simulation.NewNormalAccuracyModel(float64(u.stdDevInputField))
+		return u.getNormalAccuracyModel()
 	case Mode_SearchNormal:
-		return simulation.NewNormalAccuracyModel(float64(u.stdDevInputField))
+		return u.getNormalAccuracyModel()
 	case Mode_DrawCircle:
 		// Doesn't matter what model we return, as it isn't used in this mode
 		return simulation.NewNormalAccuracyModel(float64(u.stdDevInputField))
@@ -558,10 +791,58 @@ func (u *UserInterfaceInstance) getAccuracyModel(mode InterfaceMode) simulation.
 		return simulation.NewNormalAccuracyModel(float64(u.stdDevInputField))
 	default:
 		panic("Invalid radio button value")
-		return simulation.NewPerfectAccuracyModel()
 	}
 }
 
+// getNormalAccuracyModel returns the normal model described by the standard deviation fields: the simple
+// model with the same spread in all directions, the bivariate one if separate X/Y values have been requested,
+// or the mixture model if wild throws have been requested.
+// The measured aim bias is applied to the model if requested.
+// If the recorded real throws are to be used instead, they already include the bias, and their own spread.
+func (u *UserInterfaceInstance) getNormalAccuracyModel() simulation.AccuracyModel {
+	if u.recordedThrowsCheckbox && u.realThrows.GetNumThrows() > 0 {
+		return simulation.NewEmpiricalAccuracyModel(u.realThrows.GetMissVectors(), u.smoothRecordedThrowsCheckbox)
+	}
+	var model simulation.AccuracyModel
+	if u.wildThrowsCheckbox {
+		model = simulation.NewMixtureAccuracyModel(float64(u.stdDevInputField),
+			float64(u.wildStdDevInputField),
+			float64(u.wildFractionInputField))
+	} else if u.separateXYCheckbox {
+		model = simulation.NewBivariateNormalAccuracyModel(float64(u.stdDevInputField),
+			float64(u.stdDevYInputField),
+			float64(u.correlationInputField))
+	} else {
+		model = simulation.NewNormalAccuracyModel(float64(u.stdDevInputField))
+	}
+	if u.applyAimBiasCheckbox {
+		model.SetMeanOffset(u.aimBiasX, u.aimBiasY)
+	}
+	return model
+}
+
+// isGaussianModel returns true if the current model is Gaussian, so the search can calculate exact scores
+func (u *UserInterfaceInstance) isGaussianModel() bool {
+	_, isGaussian := u.accuracyModel.(simulation.GaussianAccuracyModel)
+	return isGaussian
+}
+
+// canSweepModel returns true if the current model has a single standard deviation for a sigma sweep to vary.  The
+// separate X/Y model's setting changes only the horizontal spread, so sweeping it would mislabel the chart.
+func (u *UserInterfaceInstance) canSweepModel() bool {
+	_, isBivariate := u.accuracyModel.(*simulation.BivariateNormalAccuracyModel)
+	return !isBivariate
+}
+
+// aimBiasString describes the measured aim bias for display, e.g. "5mm low, 2mm right"
+func (u *UserInterfaceInstance) aimBiasString() string {
+	description := target_search.DescribeOffset(u.aimBiasX, u.aimBiasY)
+	if description == "" {
+		return "None"
+	}
+	return description
+}
+
 // radioChanged responds to a change to the mode radio button by resetting various display fields and counters
 func (u *UserInterfaceInstance) radioChanged() {
 	u.scoreDisplay = ""
@@ -688,6 +969,7 @@ func (u *UserInterfaceInstance) handleEmpiricalModeClick(position boardgeo.Board
 			stdDev := u.realThrows.CalcStdDevOfThrows()
 			u.stdDevInputField = float32(stdDev)
 			u.setStandardDeviation(stdDev)
+			u.aimBiasX, u.aimBiasY = u.realThrows.CalcMeanOffsetOfThrows()
 		}
 		u.messageDisplay = "Throw, click hits"
 	default:
@@ -697,9 +979,21 @@ func (u *UserInterfaceInstance) handleEmpiricalModeClick(position boardgeo.Board
 
 func (u *UserInterfaceInstance) setStandardDeviation(stdDev float64) {
 	u.accuracyModel.SetStandardDeviation(stdDev)
-	u.dartboard.SetDrawOneSigma(u.drawOneSigma, u.accuracyModel.GetSigmaRadius(1))
-	u.dartboard.SetDrawTwoSigma(u.drawTwoSigma, u.accuracyModel.GetSigmaRadius(2))
-	u.dartboard.SetDrawThreeSigma(u.drawThreeSigma, u.accuracyModel.GetSigmaRadius(3))
+	u.refreshSigmaContours()
+}
+
+// refreshAccuracyModel rebuilds the accuracy model from the input fields and redraws the standard deviation
+// ellipses to match.  (The model is also rebuilt on every UI pass, but the ellipses are only set on request.)
+func (u *UserInterfaceInstance) refreshAccuracyModel() {
+	u.accuracyModel = u.getAccuracyModel(u.mode)
+	u.refreshSigmaContours()
+}
+
+// refreshSigmaContours passes the 1, 2, and 3 standard deviation contours of the current model to the dartboard
+func (u *UserInterfaceInstance) refreshSigmaContours() {
+	u.dartboard.SetDrawOneSigma(u.drawOneSigma, u.accuracyModel.GetSigmaEllipse(1))
+	u.dartboard.SetDrawTwoSigma(u.drawTwoSigma, u.accuracyModel.GetSigmaEllipse(2))
+	u.dartboard.SetDrawThreeSigma(u.drawThreeSigma, u.accuracyModel.GetSigmaEllipse(3))
 }
 
 func (u *UserInterfaceInstance) loadRealThrowData() {
@@ -734,6 +1028,7 @@ func (u *UserInterfaceInstance) loadRealThrowData() {
 	stdev := u.realThrows.CalcStdDevOfThrows()
 	u.setStandardDeviation(stdev)
 	u.stdDevInputField = float32(stdev)
+	u.aimBiasX, u.aimBiasY = u.realThrows.CalcMeanOffsetOfThrows()
 }
 
 func (u *UserInterfaceInstance) saveRealThrowData() {